@@ -5,13 +5,14 @@ go 1.17
 require (
 	github.com/google/go-querystring v1.1.0
 	github.com/gorilla/websocket v1.5.0
+	github.com/kilic/bls12-381 v0.1.0
 	github.com/patrickmn/go-cache v2.1.0+incompatible
 	github.com/stretchr/testify v1.8.0
-	gopkg.in/yaml.v2 v2.4.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	golang.org/x/sys v0.7.0 // indirect
 )
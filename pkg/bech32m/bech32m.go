@@ -25,7 +25,7 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/forks-lab/go-stai-libs/pkg/types"
+	"github.com/forks-lab/go-stai-libs/pkg/profile"
 )
 
 var charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
@@ -164,8 +164,12 @@ func convertbits(data []byte, frombits, tobits uint, pad bool) ([]byte, error) {
 }
 
 // EncodePuzzleHash encode to an address
+// Use EncodePuzzleHashForProfile to encode with the address prefix of a profile
 func EncodePuzzleHash(puzzleHash [32]byte, prefix string) (string, error) {
-	data, err := convertbits(types.Bytes32ToBytes(puzzleHash), 8, 5, true)
+	if prefix == "" {
+		return "", fmt.Errorf("address prefix is empty")
+	}
+	data, err := convertbits(puzzleHash[:], 8, 5, true)
 	if err != nil {
 		return "", err
 	}
@@ -190,9 +194,31 @@ func DecodePuzzleHash(addr string) (string, [32]byte, error) {
 	if err != nil {
 		return "", [32]byte{}, err
 	}
-	b, err := types.BytesToBytes32(res)
-	if err != nil {
-		return "", [32]byte{}, err
+	if len(res) != 32 {
+		return "", [32]byte{}, fmt.Errorf("expected 32 byte puzzle hash, got %d bytes", len(res))
 	}
+	var b [32]byte
+	copy(b[:], res)
 	return hrp, b, nil
 }
+
+// EncodePuzzleHashForProfile encode to an address using the address prefix of the profile
+func EncodePuzzleHashForProfile(puzzleHash [32]byte, p *profile.Profile) (string, error) {
+	if p.AddressPrefix == "" {
+		return "", fmt.Errorf("profile %s has no address prefix", p.Name)
+	}
+	return EncodePuzzleHash(puzzleHash, p.AddressPrefix)
+}
+
+// DecodePuzzleHashForProfile Decodes an address to a puzzle hash, and returns an error if the
+// address does not use the address prefix of the profile
+func DecodePuzzleHashForProfile(addr string, p *profile.Profile) ([32]byte, error) {
+	hrp, puzzleHash, err := DecodePuzzleHash(addr)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if hrp != p.AddressPrefix {
+		return [32]byte{}, fmt.Errorf("address prefix %s does not match %s prefix %s", hrp, p.Name, p.AddressPrefix)
+	}
+	return puzzleHash, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/forks-lab/go-stai-libs/pkg/bech32m"
+	"github.com/forks-lab/go-stai-libs/pkg/profile"
 	"github.com/forks-lab/go-stai-libs/pkg/types"
 )
 
@@ -42,3 +43,24 @@ func TestKnownAddressConversions(t *testing.T) {
 		}
 	}
 }
+
+func TestPuzzleHashForProfile(t *testing.T) {
+	puzzleHash, err := types.Bytes32FromHexString("000000000000000000000000000000000000000000000000000000000000dead")
+	assert.NoError(t, err)
+
+	address, err := bech32m.EncodePuzzleHashForProfile(puzzleHash, profile.Chia)
+	assert.NoError(t, err)
+	assert.Equal(t, "xch1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqm6ks6e8mvy", address)
+
+	decoded, err := bech32m.DecodePuzzleHashForProfile(address, profile.Chia)
+	assert.NoError(t, err)
+	assert.Equal(t, [32]byte(puzzleHash), decoded)
+
+	_, err = bech32m.DecodePuzzleHashForProfile(address, profile.STAI)
+	assert.Error(t, err)
+}
+
+func TestEncodePuzzleHashEmptyPrefix(t *testing.T) {
+	_, err := bech32m.EncodePuzzleHash([32]byte{}, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,246 @@
+package bls
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	kbls "github.com/kilic/bls12-381"
+
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+const (
+	// PublicKeySize is the size of a compressed G1 point
+	PublicKeySize = 48
+	// SignatureSize is the size of a compressed G2 point
+	SignatureSize = 96
+	// SecretKeySize is the size of a serialized secret key
+	SecretKeySize = 32
+)
+
+// groupOrder is the order of G1 and G2. Secret keys and exponents are reduced modulo this
+var groupOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// GroupOrder returns the order of G1 and G2
+func GroupOrder() *big.Int {
+	return new(big.Int).Set(groupOrder)
+}
+
+// PublicKey is a point on G1
+type PublicKey struct {
+	point *kbls.PointG1
+}
+
+// PublicKeyFromBytes parses a compressed G1 point, and checks it is on the curve and in the correct subgroup
+func PublicKeyFromBytes(b []byte) (*PublicKey, error) {
+	if len(b) != PublicKeySize {
+		return nil, fmt.Errorf("bls: public key must be %d bytes, got %d", PublicKeySize, len(b))
+	}
+	point, err := kbls.NewG1().FromCompressed(b)
+	if err != nil {
+		return nil, fmt.Errorf("bls: invalid public key: %w", err)
+	}
+	return &PublicKey{point: point}, nil
+}
+
+// PublicKeyFromElement parses a public key as returned by the RPCs
+func PublicKeyFromElement(e types.G1Element) (*PublicKey, error) {
+	b, err := e.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return PublicKeyFromBytes(b)
+}
+
+// PublicKeyForExponent returns the generator of G1 multiplied by the exponent, reduced modulo the group order
+// This is the public key of the secret key with the same value
+func PublicKeyForExponent(exponent *big.Int) *PublicKey {
+	e := new(big.Int).Mod(exponent, groupOrder)
+	g1 := kbls.NewG1()
+	return &PublicKey{point: g1.MulScalarBig(g1.New(), g1.One(), e)}
+}
+
+// Bytes returns the compressed point
+func (pk *PublicKey) Bytes() []byte {
+	return kbls.NewG1().ToCompressed(pk.copyPoint())
+}
+
+// copyPoint returns a copy of the point, since the library converts points to affine form in place
+func (pk *PublicKey) copyPoint() *kbls.PointG1 {
+	return new(kbls.PointG1).Set(pk.point)
+}
+
+// Element returns the public key the same way the RPCs do
+func (pk *PublicKey) Element() types.G1Element {
+	return types.G1Element("0x" + hex.EncodeToString(pk.Bytes()))
+}
+
+// String returns the hex encoded public key
+func (pk *PublicKey) String() string {
+	return hex.EncodeToString(pk.Bytes())
+}
+
+// Equal returns true if both public keys are the same point
+func (pk *PublicKey) Equal(other *PublicKey) bool {
+	return kbls.NewG1().Equal(pk.point, other.point)
+}
+
+// IsInfinity returns true if the public key is the point at infinity
+func (pk *PublicKey) IsInfinity() bool {
+	return kbls.NewG1().IsZero(pk.point)
+}
+
+// AggregatePublicKeys adds the public keys together
+func AggregatePublicKeys(keys ...*PublicKey) *PublicKey {
+	g1 := kbls.NewG1()
+	sum := g1.Zero()
+	for _, key := range keys {
+		g1.Add(sum, sum, key.point)
+	}
+	return &PublicKey{point: sum}
+}
+
+// Signature is a point on G2
+type Signature struct {
+	point *kbls.PointG2
+}
+
+// SignatureFromBytes parses a compressed G2 point, and checks it is on the curve and in the correct subgroup
+func SignatureFromBytes(b []byte) (*Signature, error) {
+	if len(b) != SignatureSize {
+		return nil, fmt.Errorf("bls: signature must be %d bytes, got %d", SignatureSize, len(b))
+	}
+	point, err := kbls.NewG2().FromCompressed(b)
+	if err != nil {
+		return nil, fmt.Errorf("bls: invalid signature: %w", err)
+	}
+	return &Signature{point: point}, nil
+}
+
+// SignatureFromElement parses a signature as returned by the RPCs
+func SignatureFromElement(e types.G2Element) (*Signature, error) {
+	b, err := e.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return SignatureFromBytes(b)
+}
+
+// Bytes returns the compressed point
+func (s *Signature) Bytes() []byte {
+	return kbls.NewG2().ToCompressed(s.copyPoint())
+}
+
+// copyPoint returns a copy of the point, since the library converts points to affine form in place
+func (s *Signature) copyPoint() *kbls.PointG2 {
+	return new(kbls.PointG2).Set(s.point)
+}
+
+// Element returns the signature the same way the RPCs do
+func (s *Signature) Element() types.G2Element {
+	return types.G2Element("0x" + hex.EncodeToString(s.Bytes()))
+}
+
+// String returns the hex encoded signature
+func (s *Signature) String() string {
+	return hex.EncodeToString(s.Bytes())
+}
+
+// Equal returns true if both signatures are the same point
+func (s *Signature) Equal(other *Signature) bool {
+	return kbls.NewG2().Equal(s.point, other.point)
+}
+
+// IsInfinity returns true if the signature is the point at infinity, which is the aggregate of no signatures
+func (s *Signature) IsInfinity() bool {
+	return kbls.NewG2().IsZero(s.point)
+}
+
+// AggregateSignatures adds the signatures together
+func AggregateSignatures(signatures ...*Signature) *Signature {
+	g2 := kbls.NewG2()
+	sum := g2.Zero()
+	for _, signature := range signatures {
+		g2.Add(sum, sum, signature.point)
+	}
+	return &Signature{point: sum}
+}
+
+// SecretKey is a scalar modulo the group order
+type SecretKey struct {
+	value *big.Int
+}
+
+// SecretKeyFromBytes parses a 32 byte big-endian secret key, which must be less than the group order
+func SecretKeyFromBytes(b []byte) (*SecretKey, error) {
+	if len(b) != SecretKeySize {
+		return nil, fmt.Errorf("bls: secret key must be %d bytes, got %d", SecretKeySize, len(b))
+	}
+	value := new(big.Int).SetBytes(b)
+	if value.Cmp(groupOrder) >= 0 {
+		return nil, errors.New("bls: secret key is not less than the group order")
+	}
+	return &SecretKey{value: value}, nil
+}
+
+// keyGenSalt is the HKDF salt used to derive secret keys from seeds
+const keyGenSalt = "BLS-SIG-KEYGEN-SALT-"
+
+// KeyGen derives a secret key from a seed of at least 32 bytes, the same way BasicSchemeMPL.key_gen and
+// AugSchemeMPL.key_gen do. The seed is expanded to 48 bytes with HKDF-SHA256, and reduced modulo the group order
+func KeyGen(seed []byte) (*SecretKey, error) {
+	if len(seed) < 32 {
+		return nil, fmt.Errorf("bls: seed must be at least 32 bytes, got %d", len(seed))
+	}
+
+	const okmLength = 48
+	extract := hmac.New(sha256.New, []byte(keyGenSalt))
+	extract.Write(seed)
+	extract.Write([]byte{0})
+	prk := extract.Sum(nil)
+
+	info := make([]byte, 2)
+	binary.BigEndian.PutUint16(info, okmLength)
+	var okm, previous []byte
+	for i := byte(1); len(okm) < okmLength; i++ {
+		expand := hmac.New(sha256.New, prk)
+		expand.Write(previous)
+		expand.Write(info)
+		expand.Write([]byte{i})
+		previous = expand.Sum(nil)
+		okm = append(okm, previous...)
+	}
+
+	value := new(big.Int).SetBytes(okm[:okmLength])
+	value.Mod(value, groupOrder)
+	if value.Sign() == 0 {
+		return nil, errors.New("bls: seed derives a zero secret key")
+	}
+	return &SecretKey{value: value}, nil
+}
+
+// Bytes returns the 32 byte big-endian secret key
+func (sk *SecretKey) Bytes() []byte {
+	b := make([]byte, SecretKeySize)
+	return sk.value.FillBytes(b)
+}
+
+// PublicKey returns the public key of the secret key
+func (sk *SecretKey) PublicKey() *PublicKey {
+	return PublicKeyForExponent(sk.value)
+}
+
+// sign hashes the message to G2 with the domain separation tag, and multiplies it by the secret key
+func (sk *SecretKey) sign(message, dst []byte) (*Signature, error) {
+	g2 := kbls.NewG2()
+	point, err := g2.HashToCurve(message, dst)
+	if err != nil {
+		return nil, err
+	}
+	return &Signature{point: g2.MulScalarBig(g2.New(), point, sk.value)}, nil
+}
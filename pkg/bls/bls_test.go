@@ -0,0 +1,196 @@
+package bls_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/bls"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+const g1Generator = "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+
+func secretKey(t *testing.T, b byte) *bls.SecretKey {
+	sk, err := bls.SecretKeyFromBytes(bytes.Repeat([]byte{b}, bls.SecretKeySize))
+	assert.NoError(t, err)
+	return sk
+}
+
+func TestPublicKey(t *testing.T) {
+	generator := bls.PublicKeyForExponent(big.NewInt(1))
+	assert.Equal(t, g1Generator, generator.String())
+
+	parsed, err := bls.PublicKeyFromElement(types.G1Element("0x" + g1Generator))
+	assert.NoError(t, err)
+	assert.True(t, generator.Equal(parsed))
+	assert.Equal(t, types.G1Element("0x"+g1Generator), parsed.Element())
+
+	// The exponent is reduced modulo the group order
+	wrapped := new(big.Int).Add(bls.GroupOrder(), big.NewInt(1))
+	assert.True(t, generator.Equal(bls.PublicKeyForExponent(wrapped)))
+	assert.True(t, bls.PublicKeyForExponent(big.NewInt(2)).Equal(bls.AggregatePublicKeys(generator, generator)))
+
+	infinity, err := bls.PublicKeyFromBytes(append([]byte{0xc0}, make([]byte, 47)...))
+	assert.NoError(t, err)
+	assert.True(t, infinity.IsInfinity())
+
+	_, err = bls.PublicKeyFromBytes(make([]byte, 48))
+	assert.Error(t, err)
+	_, err = bls.PublicKeyFromBytes(generator.Bytes()[:47])
+	assert.Error(t, err)
+	_, err = bls.PublicKeyFromElement(types.G1Element("0x" + strings.Repeat("ff", 48)))
+	assert.Error(t, err)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	sk := secretKey(t, 0x11)
+	pk := sk.PublicKey()
+	message := []byte("hello")
+
+	basic, err := sk.SignBasic(message)
+	assert.NoError(t, err)
+	assert.True(t, bls.VerifyBasic(pk, message, basic))
+	assert.False(t, bls.VerifyBasic(pk, []byte("hellp"), basic))
+	assert.False(t, bls.VerifyAugmented(pk, message, basic))
+
+	augmented, err := sk.SignAugmented(message)
+	assert.NoError(t, err)
+	assert.True(t, bls.VerifyAugmented(pk, message, augmented))
+	assert.False(t, bls.VerifyAugmented(secretKey(t, 0x22).PublicKey(), message, augmented))
+
+	parsed, err := bls.SignatureFromElement(augmented.Element())
+	assert.NoError(t, err)
+	assert.True(t, augmented.Equal(parsed))
+}
+
+func TestAggregateVerify(t *testing.T) {
+	sk1, sk2 := secretKey(t, 0x11), secretKey(t, 0x22)
+	pks := []*bls.PublicKey{sk1.PublicKey(), sk2.PublicKey()}
+
+	// The augmented scheme allows both keys to sign the same message
+	messages := [][]byte{[]byte("same"), []byte("same")}
+	sig1, err := sk1.SignAugmented(messages[0])
+	assert.NoError(t, err)
+	sig2, err := sk2.SignAugmented(messages[1])
+	assert.NoError(t, err)
+	aggregate := bls.AggregateSignatures(sig1, sig2)
+	assert.True(t, bls.AggregateVerifyAugmented(pks, messages, aggregate))
+	assert.False(t, bls.AggregateVerifyAugmented(pks[:1], messages[:1], aggregate))
+
+	// The basic scheme requires distinct messages
+	sig1, err = sk1.SignBasic(messages[0])
+	assert.NoError(t, err)
+	sig2, err = sk2.SignBasic(messages[1])
+	assert.NoError(t, err)
+	assert.False(t, bls.AggregateVerifyBasic(pks, messages, bls.AggregateSignatures(sig1, sig2)))
+
+	messages[1] = []byte("different")
+	sig2, err = sk2.SignBasic(messages[1])
+	assert.NoError(t, err)
+	assert.True(t, bls.AggregateVerifyBasic(pks, messages, bls.AggregateSignatures(sig1, sig2)))
+
+	assert.True(t, bls.AggregateVerifyAugmented(nil, nil, bls.AggregateSignatures()))
+	assert.False(t, bls.AggregateVerifyAugmented(nil, nil, sig1))
+}
+
+func TestVerifyFoliage(t *testing.T) {
+	sk := secretKey(t, 0x33)
+	poolPuzzleHash := types.PuzzleHash{0x0b}
+	foliage := &types.Foliage{
+		FoliageBlockData: &types.FoliageBlockData{
+			UnfinishedRewardBlockHash: types.Bytes32{0x0c},
+			PoolTarget:                &types.PoolTarget{PuzzleHash: &poolPuzzleHash},
+		},
+		FoliageTransactionBlockHash: &types.Bytes32{0x0e},
+	}
+
+	hash, err := foliage.FoliageBlockData.Hash()
+	assert.NoError(t, err)
+	blockDataSignature, err := sk.SignAugmented(hash[:])
+	assert.NoError(t, err)
+	transactionBlockSignature, err := sk.SignAugmented(foliage.FoliageTransactionBlockHash[:])
+	assert.NoError(t, err)
+
+	blockDataElement := blockDataSignature.Element()
+	transactionBlockElement := transactionBlockSignature.Element()
+	foliage.FoliageBlockDataSignature = &blockDataElement
+	foliage.FoliageTransactionBlockSignature = &transactionBlockElement
+	assert.NoError(t, bls.VerifyFoliage(foliage, sk.PublicKey()))
+	assert.Error(t, bls.VerifyFoliage(foliage, secretKey(t, 0x44).PublicKey()))
+
+	foliage.FoliageTransactionBlockSignature = &blockDataElement
+	assert.Error(t, bls.VerifyFoliage(foliage, sk.PublicKey()))
+}
+
+// TestBasicSchemeVectors checks signatures against the test vectors for the BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_
+// ciphersuite, which is the ciphersuite of BasicSchemeMPL. Each line is the message, the key generation seed, and the
+// signature. The vectors are from https://github.com/kwantam/bls_sigs_ref/tree/sgn0_fix/test-vectors/sig_g2_basic
+func TestBasicSchemeVectors(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "sig_g2_basic_P256.txt"))
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.NotEmpty(t, lines)
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if !assert.Len(t, fields, 3) {
+			continue
+		}
+		message, err := hex.DecodeString(fields[0])
+		assert.NoError(t, err)
+		seed, err := hex.DecodeString(fields[1])
+		assert.NoError(t, err)
+
+		sk, err := bls.KeyGen(seed)
+		assert.NoError(t, err)
+		signature, err := sk.SignBasic(message)
+		assert.NoError(t, err)
+		assert.Equal(t, fields[2], signature.String())
+		assert.True(t, bls.VerifyBasic(sk.PublicKey(), message, signature))
+	}
+
+	_, err = bls.KeyGen(make([]byte, 31))
+	assert.Error(t, err)
+}
+
+// TestVerifyNodeFoliage checks the foliage signatures of blocks captured from a full node, see testdata/readme.md
+func TestVerifyNodeFoliage(t *testing.T) {
+	// Shares the blocks captured for the header hash tests in the types package
+	data, err := os.ReadFile(filepath.Join("..", "types", "testdata", "get_blocks.json"))
+	if os.IsNotExist(err) {
+		t.Skip("node fixture pkg/types/testdata/get_blocks.json has not been captured, see pkg/types/testdata/readme.md")
+	}
+	assert.NoError(t, err)
+
+	var response struct {
+		Blocks []*types.FullBlock `json:"blocks"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &response))
+	transactionBlocks := 0
+	for _, block := range response.Blocks {
+		assert.NoError(t, bls.VerifyBlockFoliage(block), "height %d", block.RewardChainBlock.Height)
+		if block.Foliage.FoliageTransactionBlockSignature != nil {
+			transactionBlocks++
+		}
+
+		// Signatures made for another block data hash don't verify
+		tampered := *block.Foliage
+		tampered.FoliageBlockData = &types.FoliageBlockData{}
+		*tampered.FoliageBlockData = *block.Foliage.FoliageBlockData
+		tampered.FoliageBlockData.ExtensionData[0] ^= 0xff
+		plotKey, err := bls.PublicKeyFromElement(*block.RewardChainBlock.ProofOfSpace.PlotPublicKey)
+		assert.NoError(t, err)
+		assert.Error(t, bls.VerifyFoliage(&tampered, plotKey))
+	}
+
+	// Only transaction blocks have a foliage transaction block signature
+	assert.NotZero(t, transactionBlocks, "get_blocks.json has no transaction blocks")
+}
@@ -0,0 +1,59 @@
+package bls
+
+import (
+	"fmt"
+
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// VerifyFoliage checks the foliage signatures were made by the plot key of the block
+// The foliage block data hash is always signed. The foliage transaction block hash is signed by transaction blocks
+func VerifyFoliage(foliage *types.Foliage, plotPublicKey *PublicKey) error {
+	if foliage.FoliageBlockData == nil || foliage.FoliageBlockDataSignature == nil {
+		return fmt.Errorf("foliage has no block data or block data signature")
+	}
+	hash, err := foliage.FoliageBlockData.Hash()
+	if err != nil {
+		return err
+	}
+	if err = verifyElement(plotPublicKey, hash, *foliage.FoliageBlockDataSignature); err != nil {
+		return fmt.Errorf("foliage block data: %w", err)
+	}
+
+	if foliage.FoliageTransactionBlockHash == nil {
+		return nil
+	}
+	if foliage.FoliageTransactionBlockSignature == nil {
+		return fmt.Errorf("foliage has a transaction block hash, but no transaction block signature")
+	}
+	if err = verifyElement(plotPublicKey, *foliage.FoliageTransactionBlockHash, *foliage.FoliageTransactionBlockSignature); err != nil {
+		return fmt.Errorf("foliage transaction block: %w", err)
+	}
+	return nil
+}
+
+// VerifyBlockFoliage checks the foliage signatures of the block with the plot key from its proof of space
+func VerifyBlockFoliage(block *types.FullBlock) error {
+	if block.RewardChainBlock == nil || block.RewardChainBlock.ProofOfSpace == nil || block.RewardChainBlock.ProofOfSpace.PlotPublicKey == nil {
+		return fmt.Errorf("block has no plot public key")
+	}
+	if block.Foliage == nil {
+		return fmt.Errorf("block has no foliage")
+	}
+	plotPublicKey, err := PublicKeyFromElement(*block.RewardChainBlock.ProofOfSpace.PlotPublicKey)
+	if err != nil {
+		return err
+	}
+	return VerifyFoliage(block.Foliage, plotPublicKey)
+}
+
+func verifyElement(pk *PublicKey, hash types.Bytes32, element types.G2Element) error {
+	signature, err := SignatureFromElement(element)
+	if err != nil {
+		return err
+	}
+	if !VerifyAugmented(pk, hash[:], signature) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
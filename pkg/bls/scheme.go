@@ -0,0 +1,85 @@
+package bls
+
+import (
+	kbls "github.com/kilic/bls12-381"
+)
+
+// Domain separation tags of the signature schemes, as used by the full node
+var (
+	basicSchemeDST     = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_")
+	augmentedSchemeDST = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_")
+)
+
+// SignBasic signs the message with the basic scheme (BasicSchemeMPL)
+func (sk *SecretKey) SignBasic(message []byte) (*Signature, error) {
+	return sk.sign(message, basicSchemeDST)
+}
+
+// SignAugmented signs the message with the augmented scheme (AugSchemeMPL), which prepends the public key to the message
+// This is the scheme used for AGG_SIG conditions and block signatures
+func (sk *SecretKey) SignAugmented(message []byte) (*Signature, error) {
+	return sk.sign(augmentMessage(sk.PublicKey(), message), augmentedSchemeDST)
+}
+
+// VerifyBasic verifies a signature of the message made with the basic scheme
+func VerifyBasic(pk *PublicKey, message []byte, signature *Signature) bool {
+	return aggregateVerify([]*PublicKey{pk}, [][]byte{message}, signature, basicSchemeDST)
+}
+
+// VerifyAugmented verifies a signature of the message made with the augmented scheme
+func VerifyAugmented(pk *PublicKey, message []byte, signature *Signature) bool {
+	return aggregateVerify([]*PublicKey{pk}, [][]byte{augmentMessage(pk, message)}, signature, augmentedSchemeDST)
+}
+
+// AggregateVerifyBasic verifies an aggregate of signatures made with the basic scheme, where each message was signed
+// by the public key at the same index. The basic scheme requires the messages to be distinct
+func AggregateVerifyBasic(pks []*PublicKey, messages [][]byte, signature *Signature) bool {
+	seen := map[string]bool{}
+	for _, message := range messages {
+		if seen[string(message)] {
+			return false
+		}
+		seen[string(message)] = true
+	}
+	return aggregateVerify(pks, messages, signature, basicSchemeDST)
+}
+
+// AggregateVerifyAugmented verifies an aggregate of signatures made with the augmented scheme, where each message was
+// signed by the public key at the same index
+func AggregateVerifyAugmented(pks []*PublicKey, messages [][]byte, signature *Signature) bool {
+	if len(pks) != len(messages) {
+		return false
+	}
+	augmented := make([][]byte, len(messages))
+	for i, message := range messages {
+		augmented[i] = augmentMessage(pks[i], message)
+	}
+	return aggregateVerify(pks, augmented, signature, augmentedSchemeDST)
+}
+
+// augmentMessage prepends the public key to the message
+func augmentMessage(pk *PublicKey, message []byte) []byte {
+	return append(pk.Bytes(), message...)
+}
+
+// aggregateVerify checks e(g1, signature) == e(pk_1, H(m_1)) * ... * e(pk_n, H(m_n))
+// An aggregate of no signatures is the point at infinity
+func aggregateVerify(pks []*PublicKey, messages [][]byte, signature *Signature, dst []byte) bool {
+	if len(pks) != len(messages) {
+		return false
+	}
+	if len(pks) == 0 {
+		return signature.IsInfinity()
+	}
+
+	engine := kbls.NewEngine()
+	for i, pk := range pks {
+		point, err := engine.G2.HashToCurve(messages[i], dst)
+		if err != nil {
+			return false
+		}
+		engine.AddPair(pk.copyPoint(), point)
+	}
+	engine.AddPairInv(engine.G1.One(), signature.copyPoint())
+	return engine.Check()
+}
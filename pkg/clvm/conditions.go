@@ -0,0 +1,163 @@
+package clvm
+
+import (
+	"fmt"
+
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// ConditionOpcode is the first atom of a condition returned by a puzzle
+type ConditionOpcode byte
+
+// Condition opcodes understood by the full node
+const (
+	ConditionRemark                   ConditionOpcode = 1
+	ConditionAggSigUnsafe             ConditionOpcode = 49
+	ConditionAggSigMe                 ConditionOpcode = 50
+	ConditionCreateCoin               ConditionOpcode = 51
+	ConditionReserveFee               ConditionOpcode = 52
+	ConditionCreateCoinAnnouncement   ConditionOpcode = 60
+	ConditionAssertCoinAnnouncement   ConditionOpcode = 61
+	ConditionCreatePuzzleAnnouncement ConditionOpcode = 62
+	ConditionAssertPuzzleAnnouncement ConditionOpcode = 63
+	ConditionAssertMyCoinID           ConditionOpcode = 70
+	ConditionAssertMyParentID         ConditionOpcode = 71
+	ConditionAssertMyPuzzlehash       ConditionOpcode = 72
+	ConditionAssertMyAmount           ConditionOpcode = 73
+	ConditionAssertSecondsRelative    ConditionOpcode = 80
+	ConditionAssertSecondsAbsolute    ConditionOpcode = 81
+	ConditionAssertHeightRelative     ConditionOpcode = 82
+	ConditionAssertHeightAbsolute     ConditionOpcode = 83
+)
+
+// Costs the full node adds for conditions, on top of the cost of running the puzzle
+const (
+	CreateCoinCost uint64 = 1800000
+	AggSigCost     uint64 = 1200000
+)
+
+var conditionNames = map[ConditionOpcode]string{
+	ConditionRemark:                   "REMARK",
+	ConditionAggSigUnsafe:             "AGG_SIG_UNSAFE",
+	ConditionAggSigMe:                 "AGG_SIG_ME",
+	ConditionCreateCoin:               "CREATE_COIN",
+	ConditionReserveFee:               "RESERVE_FEE",
+	ConditionCreateCoinAnnouncement:   "CREATE_COIN_ANNOUNCEMENT",
+	ConditionAssertCoinAnnouncement:   "ASSERT_COIN_ANNOUNCEMENT",
+	ConditionCreatePuzzleAnnouncement: "CREATE_PUZZLE_ANNOUNCEMENT",
+	ConditionAssertPuzzleAnnouncement: "ASSERT_PUZZLE_ANNOUNCEMENT",
+	ConditionAssertMyCoinID:           "ASSERT_MY_COIN_ID",
+	ConditionAssertMyParentID:         "ASSERT_MY_PARENT_ID",
+	ConditionAssertMyPuzzlehash:       "ASSERT_MY_PUZZLEHASH",
+	ConditionAssertMyAmount:           "ASSERT_MY_AMOUNT",
+	ConditionAssertSecondsRelative:    "ASSERT_SECONDS_RELATIVE",
+	ConditionAssertSecondsAbsolute:    "ASSERT_SECONDS_ABSOLUTE",
+	ConditionAssertHeightRelative:     "ASSERT_HEIGHT_RELATIVE",
+	ConditionAssertHeightAbsolute:     "ASSERT_HEIGHT_ABSOLUTE",
+}
+
+// String returns the name of the condition opcode
+func (o ConditionOpcode) String() string {
+	if name, ok := conditionNames[o]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", byte(o))
+}
+
+// Condition is a single condition returned by a puzzle, such as CREATE_COIN or AGG_SIG_ME
+type Condition struct {
+	Opcode ConditionOpcode
+	Args   []*Program
+}
+
+// Arg returns the atom of the argument at index i
+func (c *Condition) Arg(i int) ([]byte, error) {
+	if i >= len(c.Args) {
+		return nil, fmt.Errorf("%s condition is missing argument %d", c.Opcode, i)
+	}
+	return c.Args[i].Atom()
+}
+
+// ParseConditions parses the list of conditions returned by a puzzle
+// Conditions with opcodes the full node does not know about are skipped, the same way the full node does
+func ParseConditions(output *Program) ([]*Condition, error) {
+	var conditions []*Condition
+	current := output
+	for ; current.IsPair(); current = current.rest {
+		item := current.first
+		if item.IsAtom() {
+			return nil, evalError(item, "condition is not a list")
+		}
+		opcode, err := item.first.Atom()
+		if err != nil {
+			return nil, evalError(item, "condition opcode is not an atom")
+		}
+		if len(opcode) != 1 {
+			continue
+		}
+		if _, ok := conditionNames[ConditionOpcode(opcode[0])]; !ok {
+			continue
+		}
+		conditions = append(conditions, &Condition{
+			Opcode: ConditionOpcode(opcode[0]),
+			Args:   listItems(item.rest),
+		})
+	}
+	if !current.IsNil() {
+		return nil, evalError(output, "conditions are not a list")
+	}
+	return conditions, nil
+}
+
+// PuzzleResult is the outcome of running a puzzle with its solution
+type PuzzleResult struct {
+	Conditions []*Condition
+	// Cost is the cost of running the puzzle
+	Cost uint64
+	// ConditionCost is the extra cost the full node charges for CREATE_COIN and AGG_SIG conditions
+	ConditionCost uint64
+}
+
+// TotalCost returns the cost of running the puzzle plus the cost of its conditions
+func (r *PuzzleResult) TotalCost() uint64 {
+	return r.Cost + r.ConditionCost
+}
+
+// RunPuzzle runs the puzzle with the solution and parses the conditions it returns
+func RunPuzzle(puzzle, solution *Program, options RunOptions) (*PuzzleResult, error) {
+	result, err := Run(puzzle, solution, options)
+	if err != nil {
+		return nil, err
+	}
+	conditions, err := ParseConditions(result.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	puzzleResult := &PuzzleResult{Conditions: conditions, Cost: result.Cost}
+	for _, condition := range conditions {
+		switch condition.Opcode {
+		case ConditionCreateCoin:
+			puzzleResult.ConditionCost += CreateCoinCost
+		case ConditionAggSigMe, ConditionAggSigUnsafe:
+			puzzleResult.ConditionCost += AggSigCost
+		}
+	}
+	return puzzleResult, nil
+}
+
+// RunCoinSolution parses and runs the puzzle reveal of the coin solution with its solution
+func RunCoinSolution(solution *types.CoinSolution, options RunOptions) (*PuzzleResult, error) {
+	if solution.PuzzleReveal == nil || solution.Solution == nil {
+		return nil, fmt.Errorf("coin solution has no puzzle reveal or solution")
+	}
+	puzzle, err := ParseSerializedProgram(*solution.PuzzleReveal)
+	if err != nil {
+		return nil, err
+	}
+	args, err := ParseSerializedProgram(*solution.Solution)
+	if err != nil {
+		return nil, err
+	}
+	return RunPuzzle(puzzle, args, options)
+}
@@ -0,0 +1,159 @@
+package clvm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+)
+
+// keywords are the names of the operators, indexed by opcode, as used by clvm_tools
+// "." marks opcodes without a name
+var keywords = strings.Fields(
+	// core opcodes 0x01-0x08
+	". q a i c f r l x " +
+		// opcodes on atoms as strings 0x09-0x0f
+		"= >s sha256 substr strlen concat . " +
+		// opcodes on atoms as ints 0x10-0x17
+		"+ - * / divmod > ash lsh " +
+		// opcodes on atoms as vectors of bools 0x18-0x1c
+		"logand logior logxor lognot . " +
+		// opcodes for bls 1381 0x1d-0x1f
+		"point_add pubkey_for_exp . " +
+		// bool opcodes 0x20-0x23
+		"not any all . " +
+		// misc 0x24
+		"softfork",
+)
+
+// keywordForAtom returns the operator name for the atom, if it has one
+func keywordForAtom(atom []byte) (string, bool) {
+	if len(atom) != 1 || int(atom[0]) >= len(keywords) {
+		return "", false
+	}
+	keyword := keywords[atom[0]]
+	return keyword, keyword != "."
+}
+
+// String disassembles the program
+func (p *Program) String() string {
+	return p.Disassemble()
+}
+
+// Disassemble returns the program as text, the same way clvm_tools' opd does
+// Atoms in operator position are shown as the operator name. Other atoms are shown as integers if they are at
+// most two bytes, quoted strings if they are printable, and hex otherwise
+func (p *Program) Disassemble() string {
+	var buf strings.Builder
+	disassemble(&buf, p, 0)
+	return buf.String()
+}
+
+// disassembleTruncated disassembles the program, stopping once the text is longer than maxLength
+// Truncated text ends with "..."
+func (p *Program) disassembleTruncated(maxLength int) string {
+	var buf strings.Builder
+	if disassemble(&buf, p, maxLength) {
+		return buf.String()
+	}
+	return buf.String()[:maxLength] + "..."
+}
+
+// disassembleStep is either an item to disassemble, or the remainder of a list that is being disassembled
+type disassembleStep struct {
+	program      *Program
+	allowKeyword bool
+	// inList is true when program is the remainder of a list, after first has been written
+	inList bool
+	first  bool
+}
+
+// disassemble writes the program to buf, using a stack so deeply nested programs can't overflow the call stack
+// Returns false if it stopped because the text is longer than maxLength. A maxLength of 0 means no limit
+func disassemble(buf *strings.Builder, p *Program, maxLength int) bool {
+	if p.IsAtom() {
+		writeAtomText(buf, p.atom, false)
+		return maxLength == 0 || buf.Len() <= maxLength
+	}
+
+	stack := []disassembleStep{{program: p, allowKeyword: true}}
+	for len(stack) > 0 {
+		if maxLength > 0 && buf.Len() > maxLength {
+			return false
+		}
+
+		step := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		current := step.program
+
+		if !step.inList {
+			if current.IsAtom() {
+				writeAtomText(buf, current.atom, step.allowKeyword)
+				continue
+			}
+			buf.WriteByte('(')
+			step = disassembleStep{program: current, allowKeyword: step.allowKeyword, inList: true, first: true}
+		}
+
+		if current.IsPair() {
+			if !step.first {
+				buf.WriteByte(' ')
+			}
+			// The first item of a list can be an operator, as can the first item of any list nested in it
+			stack = append(stack,
+				disassembleStep{program: current.rest, allowKeyword: step.allowKeyword, inList: true},
+				disassembleStep{program: current.first, allowKeyword: (step.first && step.allowKeyword) || current.first.IsPair()},
+			)
+			continue
+		}
+
+		if !current.IsNil() {
+			buf.WriteString(" . ")
+			writeAtomText(buf, current.atom, false)
+		}
+		buf.WriteByte(')')
+	}
+	return maxLength == 0 || buf.Len() <= maxLength
+}
+
+func writeAtomText(buf *strings.Builder, atom []byte, allowKeyword bool) {
+	if allowKeyword {
+		if keyword, ok := keywordForAtom(atom); ok {
+			buf.WriteString(keyword)
+			return
+		}
+	}
+
+	if len(atom) == 0 {
+		buf.WriteString("()")
+		return
+	}
+
+	if len(atom) > 2 {
+		if isPrintable(atom) {
+			quote := byte('"')
+			if bytes.IndexByte(atom, '"') >= 0 {
+				quote = '\''
+			}
+			buf.WriteByte(quote)
+			buf.Write(atom)
+			buf.WriteByte(quote)
+			return
+		}
+	} else if bytes.Equal(intToBytes(intFromBytes(atom)), atom) {
+		buf.WriteString(intFromBytes(atom).String())
+		return
+	}
+
+	buf.WriteString("0x")
+	buf.WriteString(hex.EncodeToString(atom))
+}
+
+// isPrintable matches python's string.printable, which is what clvm_tools uses to decide if an atom is shown as a string
+func isPrintable(atom []byte) bool {
+	for _, c := range atom {
+		if c < 0x20 && !strings.ContainsRune(" \t\n\r\x0b\x0c", rune(c)) || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,168 @@
+package clvm
+
+import (
+	"fmt"
+
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// BlockSpend is a single coin spend in the transactions generator of a block
+type BlockSpend struct {
+	Coin         *types.Coin
+	CoinID       types.Bytes32
+	PuzzleReveal *Program
+	Solution     *Program
+	Conditions   []*Condition
+	// Cost is the cost of running the puzzle, including the cost of its conditions
+	Cost uint64
+}
+
+// CoinSolution returns the spend in the same form the RPCs use
+func (s *BlockSpend) CoinSolution() *types.CoinSolution {
+	puzzleReveal := s.PuzzleReveal.SerializedProgram()
+	solution := s.Solution.SerializedProgram()
+	return &types.CoinSolution{
+		Coin:         s.Coin,
+		PuzzleReveal: &puzzleReveal,
+		Solution:     &solution,
+	}
+}
+
+// DecodeGenerator runs the transactions generator of a block, and returns the coin spends it contains
+// refs are the transactions generators of the blocks in the TransactionsGeneratorRefList of the block, in the same order.
+// Each puzzle is run with its solution to find the conditions of the spend
+func DecodeGenerator(generator types.SerializedProgram, refs []types.SerializedProgram, options RunOptions) ([]*BlockSpend, error) {
+	program, err := ParseSerializedProgram(generator)
+	if err != nil {
+		return nil, fmt.Errorf("parsing generator: %w", err)
+	}
+
+	// Referenced generators are passed as serialized atoms, along with the program that deserializes them
+	var refAtoms []*Program
+	for i, ref := range refs {
+		refProgram, err := ParseSerializedProgram(ref)
+		if err != nil {
+			return nil, fmt.Errorf("parsing referenced generator %d: %w", i, err)
+		}
+		refAtoms = append(refAtoms, Atom(refProgram.Bytes()))
+	}
+
+	result, err := Run(program, List(deserializerMod, List(refAtoms...)), options)
+	if err != nil {
+		return nil, fmt.Errorf("running generator: %w", err)
+	}
+
+	// The generator returns a list with the list of spends as its first item
+	spendList, err := result.Value.First()
+	if err != nil {
+		return nil, fmt.Errorf("generator returned %s instead of a list of spends", result.Value.Disassemble())
+	}
+
+	var spends []*BlockSpend
+	for _, item := range listItems(spendList) {
+		spend, err := decodeSpend(item, options)
+		if err != nil {
+			return nil, err
+		}
+		spends = append(spends, spend)
+	}
+	return spends, nil
+}
+
+// decodeSpend decodes a single (parent_id puzzle_reveal amount solution) spend and runs it
+// Spends can have more items after the solution, which are ignored
+func decodeSpend(item *Program, options RunOptions) (*BlockSpend, error) {
+	fields := listItems(item)
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("invalid spend in generator: %s", item.Disassemble())
+	}
+
+	parent, err := fields[0].Atom()
+	if err != nil || len(parent) != hashSize {
+		return nil, fmt.Errorf("invalid parent coin id in generator: %s", fields[0].Disassemble())
+	}
+	amount, err := fields[2].AsUint64()
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount in generator: %w", err)
+	}
+
+	spend := &BlockSpend{
+		Coin: &types.Coin{
+			PuzzleHash: types.PuzzleHash(fields[1].TreeHash()),
+			Amount:     types.Uint128From64(amount),
+		},
+		PuzzleReveal: fields[1],
+		Solution:     fields[3],
+	}
+	copy(spend.Coin.ParentCoinInfo[:], parent)
+	if spend.CoinID, err = spend.Coin.ID(); err != nil {
+		return nil, err
+	}
+
+	result, err := RunPuzzle(spend.PuzzleReveal, spend.Solution, options)
+	if err != nil {
+		return nil, fmt.Errorf("running coin %s: %w", spend.CoinID, err)
+	}
+	spend.Conditions = result.Conditions
+	spend.Cost = result.TotalCost()
+
+	return spend, nil
+}
+
+// deserializerMod is called by generators to deserialize the generators they reference. It takes a single atom
+// argument, and returns the program serialized at the start of it.
+// This does the same as the deserializer the full node passes to generators, but is not identical to it, so the cost
+// of running generators that use it is different from the cost the full node reports
+var deserializerMod = buildDeserializer()
+
+func buildDeserializer() *Program {
+	q := func(p *Program) *Program { return Cons(Atom([]byte{quoteOpcode}), p) }
+	op := func(opcode byte, args ...*Program) *Program { return Cons(Atom([]byte{opcode}), List(args...)) }
+	qByte := func(b byte) *Program { return q(Atom([]byte{b})) }
+	path := func(p int64) *Program { return Int64(p) }
+	// (a (i cond (q . then) (q . otherwise)) 1) only evaluates the branch that is chosen
+	ifElse := func(cond, then, otherwise *Program) *Program {
+		return op(applyOpcode, op(0x03, cond, q(then), q(otherwise)), path(1))
+	}
+
+	// The parser is run with (parser stream) as its environment, and returns (program . remaining_stream)
+	parse := func(stream *Program) *Program {
+		return op(applyOpcode, path(2), op(0x04, path(2), op(0x04, stream, Nil)))
+	}
+	firstByte := op(0x0c, path(5), Nil, q(Int64(1)))
+	remaining := op(0x0c, path(5), q(Int64(1)))
+
+	// (parser stream) -> (parser first_result) -> (first second_result) -> ((first . second) . remaining)
+	pairEnd := op(0x04, op(0x04, path(2), op(0x05, path(5))), op(0x06, path(5)))
+	pairSecond := op(applyOpcode, q(pairEnd), op(0x04, op(0x05, path(5)), op(0x04, parse(op(0x06, path(5))), Nil)))
+	pair := op(applyOpcode, q(pairSecond), op(0x04, path(2), op(0x04, parse(remaining), Nil)))
+
+	// Atoms with a size prefix are parsed with (stream mask prefix_length) as the environment. The size is the first
+	// byte masked, followed by the rest of the prefix
+	atomEnd := op(0x04, op(0x0c, path(2), path(5), path(11)), op(0x0c, path(2), path(11)))
+	size := op(0x0e,
+		qByte(0),
+		op(0x18, op(0x0c, path(2), Nil, q(Int64(1))), path(5)),
+		op(0x0c, path(2), q(Int64(1)), path(11)),
+	)
+	sizedBody := op(applyOpcode, q(atomEnd), op(0x04, path(2), op(0x04, path(11), op(0x04, op(0x10, path(11), size), Nil))))
+	sized := func(mask byte, prefixLength int64) *Program {
+		return op(applyOpcode, q(sizedBody), op(0x04, path(5), op(0x04, qByte(mask), op(0x04, q(Int64(prefixLength)), Nil))))
+	}
+	sizedAtom := ifElse(op(0x0a, firstByte, qByte(0xbf)),
+		ifElse(op(0x0a, firstByte, qByte(0xdf)),
+			ifElse(op(0x0a, firstByte, qByte(0xef)),
+				ifElse(op(0x0a, firstByte, qByte(0xf7)),
+					ifElse(op(0x0a, firstByte, qByte(0xfb)), op(0x08, q(Atom([]byte("invalid atom size")))), sized(0x03, 5)),
+					sized(0x07, 4)),
+				sized(0x0f, 3)),
+			sized(0x1f, 2)),
+		sized(0x3f, 1))
+
+	parser := ifElse(op(0x09, firstByte, qByte(consBoxMarker)),
+		pair,
+		ifElse(op(0x0a, firstByte, qByte(maxSingleByte)), sizedAtom, op(0x04, firstByte, remaining)))
+
+	// (blob) -> (parser blob), and return the program without the remaining stream
+	return op(applyOpcode, q(op(0x05, parse(path(5)))), op(0x04, q(parser), path(1)))
+}
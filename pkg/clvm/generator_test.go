@@ -0,0 +1,101 @@
+package clvm_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/clvm"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+func TestDecodeGenerator(t *testing.T) {
+	// The puzzle returns its solution as the conditions
+	puzzle := clvm.Int64(1)
+	createCoin := clvm.List(clvm.List(clvm.Int64(51), clvm.Atom(make([]byte, 32)), clvm.Int64(900)))
+	spends := clvm.List(
+		clvm.List(clvm.Atom(make([]byte, 32)), puzzle, clvm.Int64(1000), createCoin),
+		clvm.List(clvm.Atom(make([]byte, 32)), puzzle, clvm.Int64(5), clvm.List(clvm.List(clvm.Int64(1), clvm.Atom(make([]byte, 100)), clvm.Atom(make([]byte, 10000))))),
+	)
+	generator := quote(clvm.List(spends))
+
+	decoded, err := clvm.DecodeGenerator(generator.SerializedProgram(), nil, clvm.RunOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, decoded, 2) {
+		assert.Equal(t, types.PuzzleHash(puzzle.TreeHash()), decoded[0].Coin.PuzzleHash)
+		assert.True(t, decoded[0].Coin.Amount.Equals64(1000))
+		id, err := decoded[0].Coin.ID()
+		assert.NoError(t, err)
+		assert.Equal(t, id, decoded[0].CoinID)
+		if assert.Len(t, decoded[0].Conditions, 1) {
+			assert.Equal(t, clvm.ConditionCreateCoin, decoded[0].Conditions[0].Opcode)
+		}
+		assert.NoError(t, clvm.CheckPuzzleReveal(decoded[1].CoinSolution()))
+	}
+
+	// Run the referenced generator after deserializing it: (a (a 2 (c (f 5) ())) ())
+	deserializeRef := op(0x02, clvm.Int64(2), op(0x04, op(0x05, clvm.Int64(5)), clvm.Nil))
+	refGenerator := op(0x02, deserializeRef, clvm.Nil)
+	fromRef, err := clvm.DecodeGenerator(refGenerator.SerializedProgram(), []types.SerializedProgram{generator.SerializedProgram()}, clvm.RunOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, fromRef, 2) {
+		for i := range decoded {
+			assert.Equal(t, decoded[i].CoinID, fromRef[i].CoinID)
+			assert.True(t, decoded[i].Solution.Equal(fromRef[i].Solution))
+		}
+	}
+
+	// A truncated reference fails to deserialize
+	truncated := types.SerializedProgram(generator.Hex()[:40])
+	_, err = clvm.DecodeGenerator(refGenerator.SerializedProgram(), []types.SerializedProgram{truncated}, clvm.RunOptions{})
+	assert.Error(t, err)
+}
+
+// TestDecodeNodeGenerators Ensures decoding the generators of blocks captured from a full node returns the same spends
+// as the node's get_block_spends, see testdata/readme.md
+func TestDecodeNodeGenerators(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "block_spends", "*.json"))
+	assert.NoError(t, err)
+	if len(files) == 0 {
+		t.Skip("no node fixtures in testdata/block_spends have been captured, see testdata/readme.md")
+	}
+
+	withRefs := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		var fixture struct {
+			Block         *types.FullBlock          `json:"block"`
+			RefGenerators []types.SerializedProgram `json:"ref_generators"`
+			BlockSpends   []*types.CoinSolution     `json:"block_spends"`
+		}
+		assert.NoError(t, json.Unmarshal(data, &fixture), file)
+		assert.Len(t, fixture.RefGenerators, len(fixture.Block.TransactionsGeneratorRefList), file)
+		if len(fixture.RefGenerators) > 0 {
+			withRefs++
+		}
+
+		decoded, err := clvm.DecodeGenerator(*fixture.Block.TransactionsGenerator, fixture.RefGenerators, clvm.RunOptions{})
+		if !assert.NoError(t, err, file) || !assert.Len(t, decoded, len(fixture.BlockSpends), file) {
+			continue
+		}
+		for i, expected := range fixture.BlockSpends {
+			expectedID, err := expected.Coin.ID()
+			assert.NoError(t, err)
+			assert.Equal(t, expectedID, decoded[i].CoinID, "%s spend %d", file, i)
+
+			puzzleReveal, err := clvm.ParseSerializedProgram(*expected.PuzzleReveal)
+			assert.NoError(t, err)
+			assert.True(t, puzzleReveal.Equal(decoded[i].PuzzleReveal), "%s spend %d puzzle reveal", file, i)
+			solution, err := clvm.ParseSerializedProgram(*expected.Solution)
+			assert.NoError(t, err)
+			assert.True(t, solution.Equal(decoded[i].Solution), "%s spend %d solution", file, i)
+		}
+	}
+
+	// deserializerMod is only run by blocks that reference other generators
+	assert.NotZero(t, withRefs, "no fixture in testdata/block_spends references other generators")
+}
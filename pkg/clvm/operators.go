@@ -0,0 +1,695 @@
+package clvm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/forks-lab/go-stai-libs/pkg/bls"
+)
+
+// Costs of the operators, matching the reference CLVM implementation
+const (
+	ifCost    = 33
+	consCost  = 50
+	firstCost = 30
+	restCost  = 30
+	listpCost = 19
+
+	mallocCostPerByte = 10
+
+	arithBaseCost    = 99
+	arithCostPerByte = 3
+	arithCostPerArg  = 320
+
+	logBaseCost    = 100
+	logCostPerByte = 3
+	logCostPerArg  = 264
+
+	grsBaseCost    = 117
+	grsCostPerByte = 1
+
+	eqBaseCost    = 117
+	eqCostPerByte = 1
+
+	grBaseCost    = 498
+	grCostPerByte = 2
+
+	divmodBaseCost    = 1116
+	divmodCostPerByte = 6
+
+	divBaseCost    = 988
+	divCostPerByte = 4
+
+	sha256BaseCost    = 87
+	sha256CostPerArg  = 134
+	sha256CostPerByte = 2
+
+	pointAddBaseCost   = 101094
+	pointAddCostPerArg = 1343980
+
+	pubkeyBaseCost    = 1325730
+	pubkeyCostPerByte = 38
+
+	mulBaseCost                 = 92
+	mulCostPerOp                = 885
+	mulLinearCostPerByte        = 6
+	mulSquareCostPerByteDivider = 128
+
+	strlenBaseCost    = 173
+	strlenCostPerByte = 1
+
+	pathLookupBaseCost        = 40
+	pathLookupCostPerLeg      = 4
+	pathLookupCostPerZeroByte = 4
+
+	concatBaseCost    = 142
+	concatCostPerArg  = 135
+	concatCostPerByte = 3
+
+	boolBaseCost   = 200
+	boolCostPerArg = 300
+
+	ashiftBaseCost    = 596
+	ashiftCostPerByte = 3
+
+	lshiftBaseCost    = 277
+	lshiftCostPerByte = 3
+
+	lognotBaseCost    = 331
+	lognotCostPerByte = 3
+
+	applyCost = 90
+	quoteCost = 20
+
+	substrCost = 1
+
+	// maxShift is the largest shift allowed by ash and lsh
+	maxShift = 65535
+)
+
+// operatorFunc runs an operator on its evaluated arguments, returning the cost and the result
+type operatorFunc func(args *Program) (uint64, *Program, error)
+
+// operators are the operators with single byte opcodes. q and a are handled by the interpreter
+var operators map[byte]operatorFunc
+
+func init() {
+	operators = map[byte]operatorFunc{
+		0x03: opIf,
+		0x04: opCons,
+		0x05: opFirst,
+		0x06: opRest,
+		0x07: opListp,
+		0x08: opRaise,
+		0x09: opEq,
+		0x0a: opGrBytes,
+		0x0b: opSha256,
+		0x0c: opSubstr,
+		0x0d: opStrlen,
+		0x0e: opConcat,
+		0x10: opAdd,
+		0x11: opSubtract,
+		0x12: opMultiply,
+		0x13: opDiv,
+		0x14: opDivmod,
+		0x15: opGr,
+		0x16: opAsh,
+		0x17: opLsh,
+		0x18: opLogand,
+		0x19: opLogior,
+		0x1a: opLogxor,
+		0x1b: opLognot,
+		0x1d: opPointAdd,
+		0x1e: opPubkeyForExp,
+		0x20: opNot,
+		0x21: opAny,
+		0x22: opAll,
+		0x24: opSoftfork,
+	}
+}
+
+var (
+	trueProgram  = Atom([]byte{1})
+	falseProgram = Nil
+)
+
+func boolProgram(v bool) *Program {
+	if v {
+		return trueProgram
+	}
+	return falseProgram
+}
+
+// runOperator runs the operator with the opcode on args
+func runOperator(opcode []byte, args *Program, strict bool) (uint64, *Program, error) {
+	if len(opcode) == 1 {
+		if fn, ok := operators[opcode[0]]; ok {
+			return fn(args)
+		}
+	}
+	if strict {
+		return 0, nil, evalError(Atom(opcode), "unimplemented operator")
+	}
+	return unknownOperator(opcode, args)
+}
+
+// unknownOperator is a no-op that returns nil, so new operators can be added with a soft fork
+// The cost is determined by the opcode
+func unknownOperator(opcode []byte, args *Program) (uint64, *Program, error) {
+	if len(opcode) == 0 || (len(opcode) >= 2 && opcode[0] == 0xff && opcode[1] == 0xff) {
+		return 0, nil, evalError(Atom(opcode), "reserved operator")
+	}
+	if len(opcode) > 5 {
+		return 0, nil, evalError(Atom(opcode), "invalid operator")
+	}
+
+	// The two most significant bits of the last byte choose the cost function,
+	// and the bytes before it are the multiplier, starting at 1
+	costFunction := (opcode[len(opcode)-1] & 0xc0) >> 6
+	multiplier := new(big.Int).SetBytes(opcode[:len(opcode)-1]).Uint64() + 1
+
+	var cost uint64
+	switch costFunction {
+	case 0:
+		cost = 1
+	case 1:
+		// like +
+		cost = arithBaseCost
+		var size uint64
+		for _, arg := range listItems(args) {
+			if arg.IsPair() {
+				return 0, nil, evalError(arg, "unknown op on list")
+			}
+			size += uint64(len(arg.atom))
+			cost += arithCostPerArg
+		}
+		cost += size * arithCostPerByte
+	case 2:
+		// like *
+		cost = mulBaseCost
+		items := listItems(args)
+		if len(items) > 0 {
+			if items[0].IsPair() {
+				return 0, nil, evalError(items[0], "unknown op on list")
+			}
+			vs := uint64(len(items[0].atom))
+			for _, arg := range items[1:] {
+				if arg.IsPair() {
+					return 0, nil, evalError(arg, "unknown op on list")
+				}
+				rs := uint64(len(arg.atom))
+				cost += mulCostPerOp
+				cost += (rs + vs) * mulLinearCostPerByte
+				cost += (rs * vs) / mulSquareCostPerByteDivider
+				vs += rs
+			}
+		}
+	case 3:
+		// like concat
+		cost = concatBaseCost
+		var size uint64
+		for _, arg := range listItems(args) {
+			if arg.IsPair() {
+				return 0, nil, evalError(arg, "unknown op on list")
+			}
+			cost += concatCostPerArg
+			size += uint64(len(arg.atom))
+		}
+		cost += size * concatCostPerByte
+	}
+
+	if cost > (1<<32-1)/multiplier {
+		return 0, nil, evalError(Atom(opcode), "invalid operator")
+	}
+	return cost * multiplier, Nil, nil
+}
+
+// mallocCost adds the cost of allocating the result
+func mallocCost(cost uint64, result *Program) (uint64, *Program, error) {
+	return cost + uint64(len(result.atom))*mallocCostPerByte, result, nil
+}
+
+// intArg is an integer argument and the size of the atom it was decoded from
+type intArg struct {
+	value *big.Int
+	size  int
+}
+
+func intArgs(name string, args *Program) ([]intArg, error) {
+	items := listItems(args)
+	ints := make([]intArg, 0, len(items))
+	for _, arg := range items {
+		if arg.IsPair() {
+			return nil, evalError(arg, "%s requires int args", name)
+		}
+		ints = append(ints, intArg{value: intFromBytes(arg.atom), size: len(arg.atom)})
+	}
+	return ints, nil
+}
+
+func intArgsExactly(name string, args *Program, count int) ([]intArg, error) {
+	ints, err := intArgs(name, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(ints) != count {
+		if count == 1 {
+			return nil, evalError(args, "%s takes exactly 1 argument", name)
+		}
+		return nil, evalError(args, "%s takes exactly %d arguments", name, count)
+	}
+	return ints, nil
+}
+
+// atomArgsExactly returns the arguments, which must be count atoms
+func atomArgsExactly(name string, args *Program, count int) ([][]byte, error) {
+	items := listItems(args)
+	if len(items) != count {
+		if count == 1 {
+			return nil, evalError(args, "%s takes exactly 1 argument", name)
+		}
+		return nil, evalError(args, "%s takes exactly %d arguments", name, count)
+	}
+	atoms := make([][]byte, len(items))
+	for i, item := range items {
+		if item.IsPair() {
+			return nil, evalError(item, "%s on list", name)
+		}
+		atoms[i] = item.atom
+	}
+	return atoms, nil
+}
+
+// limbsForInt is the number of bytes needed for the magnitude of the value
+func limbsForInt(v *big.Int) int {
+	return (v.BitLen() + 7) >> 3
+}
+
+func opIf(args *Program) (uint64, *Program, error) {
+	items := listItems(args)
+	if len(items) != 3 {
+		return 0, nil, evalError(args, "i takes exactly 3 arguments")
+	}
+	if items[0].IsNil() {
+		return ifCost, items[2], nil
+	}
+	return ifCost, items[1], nil
+}
+
+func opCons(args *Program) (uint64, *Program, error) {
+	items := listItems(args)
+	if len(items) != 2 {
+		return 0, nil, evalError(args, "c takes exactly 2 arguments")
+	}
+	return consCost, Cons(items[0], items[1]), nil
+}
+
+func opFirst(args *Program) (uint64, *Program, error) {
+	items := listItems(args)
+	if len(items) != 1 {
+		return 0, nil, evalError(args, "f takes exactly 1 argument")
+	}
+	if items[0].IsAtom() {
+		return 0, nil, evalError(items[0], "first of non-cons")
+	}
+	return firstCost, items[0].first, nil
+}
+
+func opRest(args *Program) (uint64, *Program, error) {
+	items := listItems(args)
+	if len(items) != 1 {
+		return 0, nil, evalError(args, "r takes exactly 1 argument")
+	}
+	if items[0].IsAtom() {
+		return 0, nil, evalError(items[0], "rest of non-cons")
+	}
+	return restCost, items[0].rest, nil
+}
+
+func opListp(args *Program) (uint64, *Program, error) {
+	items := listItems(args)
+	if len(items) != 1 {
+		return 0, nil, evalError(args, "l takes exactly 1 argument")
+	}
+	return listpCost, boolProgram(items[0].IsPair()), nil
+}
+
+func opRaise(args *Program) (uint64, *Program, error) {
+	items := listItems(args)
+	if len(items) == 1 && items[0].IsAtom() {
+		return 0, nil, evalError(items[0], "clvm raise")
+	}
+	return 0, nil, evalError(args, "clvm raise")
+}
+
+func opEq(args *Program) (uint64, *Program, error) {
+	atoms, err := atomArgsExactly("=", args, 2)
+	if err != nil {
+		return 0, nil, err
+	}
+	cost := uint64(eqBaseCost + (len(atoms[0])+len(atoms[1]))*eqCostPerByte)
+	return cost, boolProgram(bytes.Equal(atoms[0], atoms[1])), nil
+}
+
+func opGrBytes(args *Program) (uint64, *Program, error) {
+	atoms, err := atomArgsExactly(">s", args, 2)
+	if err != nil {
+		return 0, nil, err
+	}
+	cost := uint64(grsBaseCost + (len(atoms[0])+len(atoms[1]))*grsCostPerByte)
+	return cost, boolProgram(bytes.Compare(atoms[0], atoms[1]) > 0), nil
+}
+
+func opSha256(args *Program) (uint64, *Program, error) {
+	cost := uint64(sha256BaseCost)
+	var size uint64
+	h := sha256.New()
+	for _, arg := range listItems(args) {
+		if arg.IsPair() {
+			return 0, nil, evalError(arg, "sha256 on list")
+		}
+		size += uint64(len(arg.atom))
+		cost += sha256CostPerArg
+		h.Write(arg.atom)
+	}
+	cost += size * sha256CostPerByte
+	return mallocCost(cost, Atom(h.Sum(nil)))
+}
+
+func opSubstr(args *Program) (uint64, *Program, error) {
+	items := listItems(args)
+	if len(items) != 2 && len(items) != 3 {
+		return 0, nil, evalError(args, "substr takes exactly 2 or 3 arguments")
+	}
+	if items[0].IsPair() {
+		return 0, nil, evalError(items[0], "substr on list")
+	}
+	s := items[0].atom
+
+	var indices []int64
+	for _, arg := range items[1:] {
+		if arg.IsPair() || len(arg.atom) > 4 {
+			return 0, nil, evalError(arg, "substr requires int32 args (with no leading zeros)")
+		}
+		indices = append(indices, intFromBytes(arg.atom).Int64())
+	}
+	start, end := indices[0], int64(len(s))
+	if len(indices) == 2 {
+		end = indices[1]
+	}
+	if end > int64(len(s)) || end < start || end < 0 || start < 0 {
+		return 0, nil, evalError(args, "invalid indices for substr")
+	}
+	return substrCost, Atom(s[start:end]), nil
+}
+
+func opStrlen(args *Program) (uint64, *Program, error) {
+	atoms, err := atomArgsExactly("strlen", args, 1)
+	if err != nil {
+		return 0, nil, err
+	}
+	size := len(atoms[0])
+	return mallocCost(uint64(strlenBaseCost+size*strlenCostPerByte), Int64(int64(size)))
+}
+
+func opConcat(args *Program) (uint64, *Program, error) {
+	cost := uint64(concatBaseCost)
+	var buf bytes.Buffer
+	for _, arg := range listItems(args) {
+		if arg.IsPair() {
+			return 0, nil, evalError(arg, "concat on list")
+		}
+		buf.Write(arg.atom)
+		cost += concatCostPerArg
+	}
+	cost += uint64(buf.Len()) * concatCostPerByte
+	return mallocCost(cost, Atom(buf.Bytes()))
+}
+
+func opAdd(args *Program) (uint64, *Program, error) {
+	ints, err := intArgs("+", args)
+	if err != nil {
+		return 0, nil, err
+	}
+	cost := uint64(arithBaseCost)
+	total := new(big.Int)
+	for _, arg := range ints {
+		total.Add(total, arg.value)
+		cost += arithCostPerArg + uint64(arg.size)*arithCostPerByte
+	}
+	return mallocCost(cost, Int(total))
+}
+
+func opSubtract(args *Program) (uint64, *Program, error) {
+	ints, err := intArgs("-", args)
+	if err != nil {
+		return 0, nil, err
+	}
+	cost := uint64(arithBaseCost)
+	total := new(big.Int)
+	for i, arg := range ints {
+		if i == 0 {
+			total.Add(total, arg.value)
+		} else {
+			total.Sub(total, arg.value)
+		}
+		cost += arithCostPerArg + uint64(arg.size)*arithCostPerByte
+	}
+	return mallocCost(cost, Int(total))
+}
+
+func opMultiply(args *Program) (uint64, *Program, error) {
+	ints, err := intArgs("*", args)
+	if err != nil {
+		return 0, nil, err
+	}
+	cost := uint64(mulBaseCost)
+	if len(ints) == 0 {
+		return mallocCost(cost, Int64(1))
+	}
+
+	v := new(big.Int).Set(ints[0].value)
+	vs := uint64(ints[0].size)
+	for _, arg := range ints[1:] {
+		rs := uint64(arg.size)
+		cost += mulCostPerOp
+		cost += (rs + vs) * mulLinearCostPerByte
+		cost += (rs * vs) / mulSquareCostPerByteDivider
+		v.Mul(v, arg.value)
+		vs = uint64(limbsForInt(v))
+	}
+	return mallocCost(cost, Int(v))
+}
+
+// floorDivMod divides rounding towards negative infinity, the same way python does
+func floorDivMod(a, b *big.Int) (*big.Int, *big.Int) {
+	q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+	if r.Sign() != 0 && (r.Sign() < 0) != (b.Sign() < 0) {
+		q.Sub(q, big.NewInt(1))
+		r.Add(r, b)
+	}
+	return q, r
+}
+
+func opDiv(args *Program) (uint64, *Program, error) {
+	ints, err := intArgsExactly("/", args, 2)
+	if err != nil {
+		return 0, nil, err
+	}
+	if ints[1].value.Sign() == 0 {
+		return 0, nil, evalError(Int(ints[0].value), "div with 0")
+	}
+	cost := uint64(divBaseCost + (ints[0].size+ints[1].size)*divCostPerByte)
+	q, r := floorDivMod(ints[0].value, ints[1].value)
+	// Preserves the behavior of the original implementation of this operator
+	if q.Cmp(big.NewInt(-1)) == 0 && r.Sign() != 0 {
+		q.SetInt64(0)
+	}
+	return mallocCost(cost, Int(q))
+}
+
+func opDivmod(args *Program) (uint64, *Program, error) {
+	ints, err := intArgsExactly("divmod", args, 2)
+	if err != nil {
+		return 0, nil, err
+	}
+	if ints[1].value.Sign() == 0 {
+		return 0, nil, evalError(Int(ints[0].value), "divmod with 0")
+	}
+	cost := uint64(divmodBaseCost + (ints[0].size+ints[1].size)*divmodCostPerByte)
+	q, r := floorDivMod(ints[0].value, ints[1].value)
+	qProgram, rProgram := Int(q), Int(r)
+	cost += uint64(len(qProgram.atom)+len(rProgram.atom)) * mallocCostPerByte
+	return cost, Cons(qProgram, rProgram), nil
+}
+
+func opGr(args *Program) (uint64, *Program, error) {
+	ints, err := intArgsExactly(">", args, 2)
+	if err != nil {
+		return 0, nil, err
+	}
+	cost := uint64(grBaseCost + (ints[0].size+ints[1].size)*grCostPerByte)
+	return cost, boolProgram(ints[0].value.Cmp(ints[1].value) > 0), nil
+}
+
+// shiftAmount validates the shift argument of ash and lsh
+func shiftAmount(name string, args *Program, arg intArg) (int, error) {
+	if arg.size > 4 {
+		return 0, evalError(listItems(args)[1], "%s requires int32 args (with no leading zeros)", name)
+	}
+	if !arg.value.IsInt64() || arg.value.Int64() > maxShift || arg.value.Int64() < -maxShift {
+		return 0, evalError(Int(arg.value), "shift too large")
+	}
+	return int(arg.value.Int64()), nil
+}
+
+func shift(v *big.Int, amount int) *big.Int {
+	if amount >= 0 {
+		return new(big.Int).Lsh(v, uint(amount))
+	}
+	// Rsh on a negative big.Int rounds towards negative infinity, the same as python
+	return new(big.Int).Rsh(v, uint(-amount))
+}
+
+func opAsh(args *Program) (uint64, *Program, error) {
+	ints, err := intArgsExactly("ash", args, 2)
+	if err != nil {
+		return 0, nil, err
+	}
+	amount, err := shiftAmount("ash", args, ints[1])
+	if err != nil {
+		return 0, nil, err
+	}
+	r := shift(ints[0].value, amount)
+	cost := uint64(ashiftBaseCost + (ints[0].size+limbsForInt(r))*ashiftCostPerByte)
+	return mallocCost(cost, Int(r))
+}
+
+func opLsh(args *Program) (uint64, *Program, error) {
+	ints, err := intArgsExactly("lsh", args, 2)
+	if err != nil {
+		return 0, nil, err
+	}
+	amount, err := shiftAmount("lsh", args, ints[1])
+	if err != nil {
+		return 0, nil, err
+	}
+	// The value is shifted as an unsigned integer
+	unsigned := new(big.Int).SetBytes(listItems(args)[0].atom)
+	r := shift(unsigned, amount)
+	cost := uint64(lshiftBaseCost + (ints[0].size+limbsForInt(r))*lshiftCostPerByte)
+	return mallocCost(cost, Int(r))
+}
+
+// binopReduction applies op to each argument in turn, starting with initial
+func binopReduction(name string, initial int64, args *Program, op func(z, x, y *big.Int) *big.Int) (uint64, *Program, error) {
+	ints, err := intArgs(name, args)
+	if err != nil {
+		return 0, nil, err
+	}
+	cost := uint64(logBaseCost)
+	total := big.NewInt(initial)
+	for _, arg := range ints {
+		op(total, total, arg.value)
+		cost += logCostPerArg + uint64(arg.size)*logCostPerByte
+	}
+	return mallocCost(cost, Int(total))
+}
+
+func opLogand(args *Program) (uint64, *Program, error) {
+	return binopReduction("logand", -1, args, (*big.Int).And)
+}
+
+func opLogior(args *Program) (uint64, *Program, error) {
+	return binopReduction("logior", 0, args, (*big.Int).Or)
+}
+
+func opLogxor(args *Program) (uint64, *Program, error) {
+	return binopReduction("logxor", 0, args, (*big.Int).Xor)
+}
+
+func opLognot(args *Program) (uint64, *Program, error) {
+	ints, err := intArgsExactly("lognot", args, 1)
+	if err != nil {
+		return 0, nil, err
+	}
+	cost := uint64(lognotBaseCost + ints[0].size*lognotCostPerByte)
+	return mallocCost(cost, Int(new(big.Int).Not(ints[0].value)))
+}
+
+func opNot(args *Program) (uint64, *Program, error) {
+	items := listItems(args)
+	if len(items) != 1 {
+		return 0, nil, evalError(args, "not takes exactly 1 argument")
+	}
+	return boolBaseCost, boolProgram(items[0].IsNil()), nil
+}
+
+func opAny(args *Program) (uint64, *Program, error) {
+	items := listItems(args)
+	cost := uint64(boolBaseCost + len(items)*boolCostPerArg)
+	for _, item := range items {
+		if !item.IsNil() {
+			return cost, trueProgram, nil
+		}
+	}
+	return cost, falseProgram, nil
+}
+
+func opAll(args *Program) (uint64, *Program, error) {
+	items := listItems(args)
+	cost := uint64(boolBaseCost + len(items)*boolCostPerArg)
+	for _, item := range items {
+		if item.IsNil() {
+			return cost, falseProgram, nil
+		}
+	}
+	return cost, trueProgram, nil
+}
+
+func opSoftfork(args *Program) (uint64, *Program, error) {
+	items := listItems(args)
+	if len(items) < 1 {
+		return 0, nil, evalError(args, "softfork takes at least 1 argument")
+	}
+	if items[0].IsPair() {
+		return 0, nil, evalError(items[0], "softfork requires int args")
+	}
+	cost := intFromBytes(items[0].atom)
+	if cost.Sign() < 1 {
+		return 0, nil, evalError(args, "cost must be > 0")
+	}
+	if !cost.IsUint64() {
+		return 0, nil, ErrCostExceeded
+	}
+	return cost.Uint64(), falseProgram, nil
+}
+
+func opPointAdd(args *Program) (uint64, *Program, error) {
+	cost := uint64(pointAddBaseCost)
+	var keys []*bls.PublicKey
+	for _, arg := range listItems(args) {
+		if arg.IsPair() {
+			return 0, nil, evalError(arg, "point_add on list")
+		}
+		key, err := bls.PublicKeyFromBytes(arg.atom)
+		if err != nil {
+			return 0, nil, evalError(arg, "point_add expects blob")
+		}
+		keys = append(keys, key)
+		cost += pointAddCostPerArg
+	}
+	return mallocCost(cost, Atom(bls.AggregatePublicKeys(keys...).Bytes()))
+}
+
+func opPubkeyForExp(args *Program) (uint64, *Program, error) {
+	ints, err := intArgsExactly("pubkey_for_exp", args, 1)
+	if err != nil {
+		return 0, nil, err
+	}
+	exponent := new(big.Int).Mod(ints[0].value, bls.GroupOrder())
+	// The cost depends on the size of the argument, not the size of the exponent after it is reduced
+	cost := uint64(pubkeyBaseCost) + uint64(ints[0].size)*pubkeyCostPerByte
+	return mallocCost(cost, Atom(bls.PublicKeyForExponent(exponent).Bytes()))
+}
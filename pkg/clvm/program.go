@@ -0,0 +1,199 @@
+package clvm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Program is a CLVM value, which is either an atom (a string of bytes) or a pair of two programs
+// The zero length atom is nil, which is also false and the empty list
+type Program struct {
+	atom  []byte
+	first *Program
+	rest  *Program
+}
+
+// Nil is the empty atom
+var Nil = &Program{}
+
+// ErrNotPair is returned when a pair was expected, but the program is an atom
+var ErrNotPair = errors.New("clvm: expected a pair, got an atom")
+
+// ErrNotAtom is returned when an atom was expected, but the program is a pair
+var ErrNotAtom = errors.New("clvm: expected an atom, got a pair")
+
+// Atom returns a program for the atom
+func Atom(atom []byte) *Program {
+	if len(atom) == 0 {
+		return Nil
+	}
+	return &Program{atom: atom}
+}
+
+// Cons returns a pair of first and rest
+func Cons(first, rest *Program) *Program {
+	return &Program{first: first, rest: rest}
+}
+
+// List returns a nil terminated list of the items
+func List(items ...*Program) *Program {
+	list := Nil
+	for i := len(items) - 1; i >= 0; i-- {
+		list = Cons(items[i], list)
+	}
+	return list
+}
+
+// Int returns the atom for the integer, encoded the shortest way as big-endian two's complement
+func Int(v *big.Int) *Program {
+	return Atom(intToBytes(v))
+}
+
+// Int64 returns the atom for the integer
+func Int64(v int64) *Program {
+	return Int(big.NewInt(v))
+}
+
+// Uint64 returns the atom for the integer
+func Uint64(v uint64) *Program {
+	return Int(new(big.Int).SetUint64(v))
+}
+
+// IsPair returns true if the program is a pair
+func (p *Program) IsPair() bool {
+	return p.first != nil
+}
+
+// IsAtom returns true if the program is an atom
+func (p *Program) IsAtom() bool {
+	return p.first == nil
+}
+
+// IsNil returns true if the program is the empty atom
+func (p *Program) IsNil() bool {
+	return p.first == nil && len(p.atom) == 0
+}
+
+// Atom returns the bytes of the atom, or ErrNotAtom if the program is a pair
+func (p *Program) Atom() ([]byte, error) {
+	if p.IsPair() {
+		return nil, ErrNotAtom
+	}
+	return p.atom, nil
+}
+
+// First returns the first half of the pair, or ErrNotPair if the program is an atom
+func (p *Program) First() (*Program, error) {
+	if p.IsAtom() {
+		return nil, ErrNotPair
+	}
+	return p.first, nil
+}
+
+// Rest returns the second half of the pair, or ErrNotPair if the program is an atom
+func (p *Program) Rest() (*Program, error) {
+	if p.IsAtom() {
+		return nil, ErrNotPair
+	}
+	return p.rest, nil
+}
+
+// Pair returns both halves of the pair. ok is false if the program is an atom
+func (p *Program) Pair() (first *Program, rest *Program, ok bool) {
+	return p.first, p.rest, p.IsPair()
+}
+
+// AsInt returns the atom as a signed big-endian two's complement integer
+func (p *Program) AsInt() (*big.Int, error) {
+	atom, err := p.Atom()
+	if err != nil {
+		return nil, err
+	}
+	return intFromBytes(atom), nil
+}
+
+// AsUint64 returns the atom as an unsigned integer, or an error if the value is negative or too large
+func (p *Program) AsUint64() (uint64, error) {
+	v, err := p.AsInt()
+	if err != nil {
+		return 0, err
+	}
+	if v.Sign() < 0 || !v.IsUint64() {
+		return 0, fmt.Errorf("clvm: %s is not a valid uint64", v.String())
+	}
+	return v.Uint64(), nil
+}
+
+// Items returns the items of a nil terminated list
+func (p *Program) Items() ([]*Program, error) {
+	var items []*Program
+	for current := p; ; current = current.rest {
+		if current.IsAtom() {
+			if !current.IsNil() {
+				return nil, errors.New("clvm: list is not nil terminated")
+			}
+			return items, nil
+		}
+		items = append(items, current.first)
+	}
+}
+
+// Equal returns true if both programs have the same structure and atoms
+func (p *Program) Equal(other *Program) bool {
+	pending := [][2]*Program{{p, other}}
+	for len(pending) > 0 {
+		a, b := pending[len(pending)-1][0], pending[len(pending)-1][1]
+		pending = pending[:len(pending)-1]
+
+		if a == b {
+			continue
+		}
+		if a.IsPair() != b.IsPair() {
+			return false
+		}
+		if a.IsPair() {
+			pending = append(pending, [2]*Program{a.first, b.first}, [2]*Program{a.rest, b.rest})
+			continue
+		}
+		if string(a.atom) != string(b.atom) {
+			return false
+		}
+	}
+	return true
+}
+
+// intFromBytes decodes big-endian two's complement bytes. No bytes is zero
+func intFromBytes(b []byte) *big.Int {
+	v := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	return v
+}
+
+// intToBytes encodes the integer as the shortest big-endian two's complement bytes. Zero is no bytes
+func intToBytes(v *big.Int) []byte {
+	if v.Sign() == 0 {
+		return []byte{}
+	}
+	if v.Sign() > 0 {
+		b := v.Bytes()
+		if b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return b
+	}
+
+	// Negative values are encoded as the two's complement in the smallest number of bytes where the sign bit is set
+	size := (v.BitLen() + 8) / 8
+	twos := new(big.Int).Add(v, new(big.Int).Lsh(big.NewInt(1), uint(size*8)))
+	b := twos.Bytes()
+	for len(b) < size {
+		b = append([]byte{0xff}, b...)
+	}
+	for len(b) > 1 && b[0] == 0xff && b[1]&0x80 != 0 {
+		b = b[1:]
+	}
+	return b
+}
@@ -0,0 +1,113 @@
+package clvm_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/clvm"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// (a (q 2 2 (c 2 (c 5 ()))) (c (q . "hello") 1))
+const testProgram = "0xff02ffff01ff02ff02ffff04ff02ffff04ff05ff80808080ffff04ffff018568656c6c6fff018080"
+
+func TestParseRoundTrip(t *testing.T) {
+	program, err := clvm.ParseHex(testProgram)
+	assert.NoError(t, err)
+	assert.Equal(t, testProgram, program.Hex())
+
+	large := clvm.Atom(make([]byte, 0x2000))
+	parsed, err := clvm.Parse(large.Bytes())
+	assert.NoError(t, err)
+	assert.True(t, large.Equal(parsed))
+	assert.Equal(t, []byte{0xe0, 0x20, 0x00}, large.Bytes()[:3])
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := clvm.ParseHex("ff01")
+	assert.ErrorIs(t, err, clvm.ErrTruncated)
+
+	_, err = clvm.ParseHex("8301")
+	assert.ErrorIs(t, err, clvm.ErrTruncated)
+
+	_, err = clvm.ParseHex("8080")
+	assert.Error(t, err)
+
+	_, err = clvm.ParseHex("ff01fe01")
+	assert.Error(t, err)
+}
+
+func TestTreeHash(t *testing.T) {
+	assert.Equal(t, "0x4bf5122f344554c53bde2ebb8cd2b7e3d1600ad631c385a5d7cce23c7785459a", clvm.Nil.TreeHash().String())
+
+	program, err := clvm.ParseHex(testProgram)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x3707ac7a5fd1faeec9ef61c795e52514f24c48fc2e7850c0271a6e99841a29ec", program.TreeHash().String())
+}
+
+func TestCheckPuzzleReveal(t *testing.T) {
+	puzzleHash, err := types.PuzzleHashFromHexString("2c34fdec3d27e365c91fd4d4e75d460349f7eae144ecccaa78265b2100803935")
+	assert.NoError(t, err)
+	reveal := types.SerializedProgram("0xff01ff8300aabb80")
+	solution := &types.CoinSolution{
+		Coin:         &types.Coin{PuzzleHash: puzzleHash},
+		PuzzleReveal: &reveal,
+	}
+	assert.NoError(t, clvm.CheckPuzzleReveal(solution))
+
+	solution.Coin.PuzzleHash = types.PuzzleHash{}
+	assert.Error(t, clvm.CheckPuzzleReveal(solution))
+}
+
+func TestDisassemble(t *testing.T) {
+	program, err := clvm.ParseHex(testProgram)
+	assert.NoError(t, err)
+	assert.Equal(t, `(a (q 2 2 (c 2 (c 5 ()))) (c (q . "hello") 1))`, program.Disassemble())
+
+	tests := map[string]*clvm.Program{
+		"()":               clvm.Nil,
+		"1":                clvm.Int64(1),
+		"-1":               clvm.Int64(-1),
+		"128":              clvm.Int64(128),
+		"0x0001":           clvm.Atom([]byte{0, 1}),
+		"0x00aabb":         clvm.Atom([]byte{0, 0xaa, 0xbb}),
+		`'say "hi"'`:       clvm.Atom([]byte(`say "hi"`)),
+		"(q 1 . 2)":        clvm.Cons(clvm.Int64(1), clvm.Cons(clvm.Int64(1), clvm.Int64(2))),
+		"(() (sha256) ())": clvm.List(clvm.Nil, clvm.List(clvm.Int64(11)), clvm.Nil),
+	}
+	for expected, program := range tests {
+		assert.Equal(t, expected, program.Disassemble())
+	}
+
+	// Deeply nested programs are disassembled without recursion
+	deep := clvm.Nil
+	for i := 0; i < 100000; i++ {
+		deep = clvm.List(deep)
+	}
+	assert.Equal(t, strings.Repeat("(", 100000)+"()"+strings.Repeat(")", 100000), deep.Disassemble())
+}
+
+func TestInts(t *testing.T) {
+	for _, v := range []int64{0, 1, -1, 127, 128, -128, -129, 255, 256, -256, -32768, 1 << 40} {
+		program := clvm.Int64(v)
+		parsed, err := program.AsInt()
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewInt(v), parsed)
+	}
+
+	assert.Equal(t, []byte{0x00, 0x80}, mustAtom(t, clvm.Int64(128)))
+	assert.Equal(t, []byte{0x80}, mustAtom(t, clvm.Int64(-128)))
+	assert.Equal(t, []byte{0xff, 0x7f}, mustAtom(t, clvm.Int64(-129)))
+
+	_, err := clvm.Int64(-1).AsUint64()
+	assert.Error(t, err)
+}
+
+func mustAtom(t *testing.T, program *clvm.Program) []byte {
+	atom, err := program.Atom()
+	assert.NoError(t, err)
+	return atom
+}
@@ -0,0 +1,233 @@
+package clvm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxCost is the maximum cost of running a program when RunOptions.MaxCost is not set
+// This is the maximum CLVM cost of a block
+const DefaultMaxCost uint64 = 11000000000
+
+const (
+	quoteOpcode = 0x01
+	applyOpcode = 0x02
+
+	// evalCost is the cost of evaluating an operator, in addition to the cost of the operator itself
+	evalCost = 1
+)
+
+// ErrCostExceeded is returned when running a program costs more than the maximum cost
+var ErrCostExceeded = errors.New("clvm: cost exceeded")
+
+// EvalError is returned when a program raises an error, or an operator is used incorrectly
+type EvalError struct {
+	Message string
+	// Program is the value the error relates to, such as the arguments of the failing operator
+	Program *Program
+}
+
+// maxErrorProgramLength limits how much of the program is included in the error message
+const maxErrorProgramLength = 256
+
+func (e *EvalError) Error() string {
+	if e.Program == nil {
+		return fmt.Sprintf("clvm: %s", e.Message)
+	}
+	return fmt.Sprintf("clvm: %s: %s", e.Message, e.Program.disassembleTruncated(maxErrorProgramLength))
+}
+
+func evalError(program *Program, format string, a ...interface{}) error {
+	return &EvalError{Message: fmt.Sprintf(format, a...), Program: program}
+}
+
+// RunOptions configures how programs are run
+type RunOptions struct {
+	MaxCost uint64 // not required, defaults to DefaultMaxCost
+	Strict  bool   // not required. Fails on unknown operators, the same way the mempool does
+}
+
+// RunResult is the value a program returned and the cost of running it
+type RunResult struct {
+	Value *Program
+	Cost  uint64
+}
+
+// steps of the machine, pushed onto the step stack while running
+const (
+	stepEval = iota
+	stepApply
+	stepCons
+	stepSwap
+)
+
+// Run runs the program with args as the environment
+// Programs are run with an explicit stack, so deeply recursive programs are limited by cost rather than the goroutine stack
+func Run(program, args *Program, options RunOptions) (*RunResult, error) {
+	maxCost := options.MaxCost
+	if maxCost == 0 {
+		maxCost = DefaultMaxCost
+	}
+
+	values := []*Program{Cons(program, args)}
+	steps := []int{stepEval}
+	var cost uint64
+
+	for len(steps) > 0 {
+		step := steps[len(steps)-1]
+		steps = steps[:len(steps)-1]
+
+		var opCost uint64
+		var err error
+		switch step {
+		case stepEval:
+			opCost, values, steps, err = eval(values, steps)
+		case stepApply:
+			opCost, values, steps, err = apply(values, steps, options.Strict)
+		case stepCons:
+			// The value just evaluated is on top of the list of values evaluated before it
+			first := values[len(values)-1]
+			rest := values[len(values)-2]
+			values = append(values[:len(values)-2], Cons(first, rest))
+		case stepSwap:
+			values[len(values)-1], values[len(values)-2] = values[len(values)-2], values[len(values)-1]
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cost += opCost
+		if cost > maxCost {
+			return nil, ErrCostExceeded
+		}
+	}
+
+	return &RunResult{Value: values[len(values)-1], Cost: cost}, nil
+}
+
+// eval evaluates the (program . env) pair on top of the value stack
+func eval(values []*Program, steps []int) (uint64, []*Program, []int, error) {
+	pair := values[len(values)-1]
+	values = values[:len(values)-1]
+	program, env := pair.first, pair.rest
+
+	// Atoms are paths into the environment
+	if program.IsAtom() {
+		cost, value, err := traversePath(program.atom, env)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		return cost, append(values, value), steps, nil
+	}
+
+	operator, operands := program.first, program.rest
+
+	// ((X) . operands) applies X to the operands without evaluating them
+	if operator.IsPair() {
+		newOperator, mustBeNil := operator.first, operator.rest
+		if newOperator.IsPair() || !mustBeNil.IsNil() {
+			return 0, nil, nil, evalError(program, "in ((X)...) syntax X must be lone atom")
+		}
+		return applyCost, append(values, newOperator, operands), append(steps, stepApply), nil
+	}
+
+	if len(operator.atom) == 1 && operator.atom[0] == quoteOpcode {
+		return quoteCost, append(values, operands), steps, nil
+	}
+
+	// Evaluate each operand, building the list of results, then apply the operator to it
+	steps = append(steps, stepApply)
+	values = append(values, operator)
+	for current := operands; !current.IsNil(); current = current.rest {
+		if current.IsAtom() {
+			return 0, nil, nil, evalError(program, "bad operand list")
+		}
+		values = append(values, Cons(current.first, env))
+		steps = append(steps, stepCons, stepEval, stepSwap)
+	}
+	values = append(values, Nil)
+
+	return evalCost, values, steps, nil
+}
+
+// apply runs the operator on the evaluated operands on top of the value stack
+func apply(values []*Program, steps []int, strict bool) (uint64, []*Program, []int, error) {
+	operands := values[len(values)-1]
+	operator := values[len(values)-2]
+	values = values[:len(values)-2]
+
+	if operator.IsPair() {
+		return 0, nil, nil, evalError(operator, "internal error")
+	}
+
+	if len(operator.atom) == 1 && operator.atom[0] == applyOpcode {
+		items := listItems(operands)
+		if len(items) != 2 {
+			return 0, nil, nil, evalError(operands, "apply requires exactly 2 parameters")
+		}
+		return applyCost, append(values, Cons(items[0], items[1])), append(steps, stepEval), nil
+	}
+
+	cost, result, err := runOperator(operator.atom, operands, strict)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	return cost, append(values, result), steps, nil
+}
+
+// traversePath follows the path encoded by the atom into the environment
+// From the least significant bit, 0 means first and 1 means rest. The most significant set bit marks the end
+func traversePath(path []byte, env *Program) (uint64, *Program, error) {
+	cost := uint64(pathLookupBaseCost + pathLookupCostPerLeg)
+	if len(path) == 0 {
+		return cost, Nil, nil
+	}
+
+	endByte := 0
+	for endByte < len(path) && path[endByte] == 0 {
+		endByte++
+	}
+	cost += uint64(endByte) * pathLookupCostPerZeroByte
+	if endByte == len(path) {
+		return cost, Nil, nil
+	}
+
+	// The most significant set bit of the first non-zero byte marks the end of the path
+	endBitmask := byte(0x80)
+	for path[endByte]&endBitmask == 0 {
+		endBitmask >>= 1
+	}
+
+	current := env
+	byteCursor := len(path) - 1
+	bitmask := byte(0x01)
+	for byteCursor > endByte || bitmask < endBitmask {
+		if current.IsAtom() {
+			return 0, nil, evalError(current, "path into atom")
+		}
+		if path[byteCursor]&bitmask != 0 {
+			current = current.rest
+		} else {
+			current = current.first
+		}
+		cost += pathLookupCostPerLeg
+
+		if bitmask == 0x80 {
+			bitmask = 0x01
+			byteCursor--
+		} else {
+			bitmask <<= 1
+		}
+	}
+
+	return cost, current, nil
+}
+
+// listItems returns the first item of each pair in the list, stopping at the first atom
+func listItems(list *Program) []*Program {
+	var items []*Program
+	for current := list; current.IsPair(); current = current.rest {
+		items = append(items, current.first)
+	}
+	return items
+}
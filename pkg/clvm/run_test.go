@@ -0,0 +1,167 @@
+package clvm_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/clvm"
+)
+
+func quote(p *clvm.Program) *clvm.Program {
+	return clvm.Cons(clvm.Int64(1), p)
+}
+
+func op(opcode int64, args ...*clvm.Program) *clvm.Program {
+	return clvm.Cons(clvm.Int64(opcode), clvm.List(args...))
+}
+
+func str(s string) *clvm.Program {
+	return quote(clvm.Atom([]byte(s)))
+}
+
+func num(v int64) *clvm.Program {
+	return quote(clvm.Int64(v))
+}
+
+func TestRun(t *testing.T) {
+	args := clvm.List(clvm.Int64(5), clvm.Int64(600))
+	tests := []struct {
+		program  *clvm.Program
+		expected string
+	}{
+		{op(0x10, num(2), num(5)), "7"},
+		{op(0x11, num(10), num(3), num(2)), "5"},
+		{op(0x12, num(3), num(-4)), "-12"},
+		{op(0x13, num(-7), num(2)), "-4"},
+		{op(0x14, num(-7), num(2)), "(-4 . 1)"},
+		{op(0x15, num(3), num(-1)), "1"},
+		{op(0x16, num(1), num(8)), "256"},
+		{op(0x17, num(-1), num(8)), "0x00ff00"},
+		{op(0x18, num(12), num(10)), "8"},
+		{op(0x03, num(1), num(2), num(3)), "2"},
+		{op(0x05, clvm.Int64(3)), "600"},
+		{op(0x06, clvm.Int64(1)), "(600)"},
+		{op(0x09, num(1), num(1)), "1"},
+		{op(0x0b, str("hello")), "0x2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{op(0x0c, str("hello"), num(1), num(4)), `"ell"`},
+		{op(0x0d, str("hello")), "5"},
+		{op(0x0e, str("he"), str("llo")), `"hello"`},
+		{op(0x20, clvm.Nil), "1"},
+		{op(0x22, num(1), clvm.Nil), "()"},
+		{op(0x1e, num(1)), "0x97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"},
+		{op(0x1d, op(0x1e, num(1)), op(0x1e, num(2))), "0x89ece308f9d1f0131765212deca99697b112d61f9be9a5f1f3780a51335b3ff981747a0b2ca2179b96d2c0c9024e5224"},
+		{op(0x02, quote(op(0x10, clvm.Int64(2), clvm.Int64(5))), quote(clvm.List(clvm.Int64(1), clvm.Int64(2)))), "3"},
+	}
+	for _, test := range tests {
+		result, err := clvm.Run(test.program, args, clvm.RunOptions{})
+		if assert.NoError(t, err, test.program.Disassemble()) {
+			assert.Equal(t, test.expected, result.Value.Disassemble(), test.program.Disassemble())
+		}
+	}
+}
+
+func TestRunCost(t *testing.T) {
+	// 1 to evaluate the operator, 20 for each quote, and 99 + 2*320 + 2*3 + 10 for +
+	result, err := clvm.Run(op(0x10, num(2), num(5)), clvm.Nil, clvm.RunOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(796), result.Cost)
+
+	// Path lookups cost 40 + 4 for each leg, plus 4 for the end
+	result, err = clvm.Run(clvm.Int64(2), clvm.Cons(clvm.Int64(5), clvm.Int64(6)), clvm.RunOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "5", result.Value.Disassemble())
+	assert.Equal(t, uint64(48), result.Cost)
+
+	// pubkey_for_exp costs 1325730 + 38 for each byte of the argument, plus 10 for each byte of the 48 byte result
+	// -1 is a single byte, even though it is reduced to a 32 byte exponent
+	result, err = clvm.Run(op(0x1e, num(-1)), clvm.Nil, clvm.RunOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1+20+1325730+38+480), result.Cost)
+}
+
+func TestRunMaxCost(t *testing.T) {
+	// (a 2 1) runs the first item of its environment with the same environment, so calls itself forever
+	loop := op(0x02, clvm.Int64(2), clvm.Int64(1))
+	_, err := clvm.Run(loop, clvm.List(loop), clvm.RunOptions{MaxCost: 100000})
+	assert.ErrorIs(t, err, clvm.ErrCostExceeded)
+
+	_, err = clvm.Run(op(0x10, num(2), num(5)), clvm.Nil, clvm.RunOptions{MaxCost: 795})
+	assert.ErrorIs(t, err, clvm.ErrCostExceeded)
+}
+
+func TestRunErrors(t *testing.T) {
+	var evalErr *clvm.EvalError
+	_, err := clvm.Run(op(0x08, num(5)), clvm.Nil, clvm.RunOptions{})
+	assert.ErrorAs(t, err, &evalErr)
+
+	_, err = clvm.Run(op(0x13, num(1), num(0)), clvm.Nil, clvm.RunOptions{})
+	assert.ErrorAs(t, err, &evalErr)
+
+	_, err = clvm.Run(op(0x05, clvm.Int64(1)), clvm.Int64(5), clvm.RunOptions{})
+	assert.ErrorAs(t, err, &evalErr)
+
+	// Unknown operators are no-ops unless running in strict mode
+	result, err := clvm.Run(op(0x30, num(1)), clvm.Nil, clvm.RunOptions{})
+	assert.NoError(t, err)
+	assert.True(t, result.Value.IsNil())
+	assert.Equal(t, uint64(22), result.Cost)
+
+	_, err = clvm.Run(op(0x30, num(1)), clvm.Nil, clvm.RunOptions{Strict: true})
+	assert.ErrorAs(t, err, &evalErr)
+
+	// Large programs are truncated in the error message
+	items := make([]*clvm.Program, 10000)
+	for i := range items {
+		items[i] = clvm.Int64(1000)
+	}
+	_, err = clvm.Run(op(0x08, quote(clvm.List(items...))), clvm.Nil, clvm.RunOptions{})
+	assert.ErrorAs(t, err, &evalErr)
+	assert.Less(t, len(err.Error()), 300)
+	assert.True(t, strings.HasSuffix(err.Error(), "..."))
+}
+
+func TestRunDeepProgram(t *testing.T) {
+	program := num(7)
+	for i := 0; i < 100000; i++ {
+		program = op(0x04, program, clvm.Nil)
+	}
+	result, err := clvm.Run(program, clvm.Nil, clvm.RunOptions{})
+	assert.NoError(t, err)
+
+	depth := 0
+	for value := result.Value; value.IsPair(); depth++ {
+		value, err = value.First()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 100000, depth)
+}
+
+func TestRunPuzzle(t *testing.T) {
+	puzzleHash := clvm.Atom(make([]byte, 32))
+	puzzle := quote(clvm.List(
+		clvm.List(clvm.Int64(51), puzzleHash, clvm.Int64(1000)),
+		clvm.List(clvm.Int64(52), clvm.Int64(10)),
+		clvm.List(clvm.Int64(99), clvm.Int64(1)),
+		clvm.List(clvm.Int64(50), clvm.Atom(make([]byte, 48)), clvm.Atom([]byte("message"))),
+	))
+
+	result, err := clvm.RunPuzzle(puzzle, clvm.Nil, clvm.RunOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, result.Conditions, 3)
+	assert.Equal(t, clvm.ConditionCreateCoin, result.Conditions[0].Opcode)
+	assert.Equal(t, "RESERVE_FEE", result.Conditions[1].Opcode.String())
+	assert.Equal(t, clvm.ConditionAggSigMe, result.Conditions[2].Opcode)
+
+	amount, err := result.Conditions[0].Args[1].AsUint64()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), amount)
+
+	assert.Equal(t, uint64(20), result.Cost)
+	assert.Equal(t, clvm.CreateCoinCost+clvm.AggSigCost, result.ConditionCost)
+	assert.Equal(t, uint64(20)+clvm.CreateCoinCost+clvm.AggSigCost, result.TotalCost())
+
+	_, err = clvm.RunPuzzle(num(5), clvm.Nil, clvm.RunOptions{})
+	assert.Error(t, err)
+}
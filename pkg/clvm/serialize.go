@@ -0,0 +1,177 @@
+package clvm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+const (
+	// consBoxMarker precedes the two halves of a serialized pair
+	consBoxMarker = 0xff
+	// backReferenceMarker precedes a back reference in the compressed serialization, which is not supported
+	backReferenceMarker = 0xfe
+	// maxSingleByte is the largest byte serialized as a single byte atom, without a size prefix
+	maxSingleByte = 0x7f
+)
+
+// ErrTruncated is returned when serialized data ends in the middle of a program
+var ErrTruncated = errors.New("clvm: serialized program is truncated")
+
+// Parse deserializes a program, and returns an error if there are bytes after the end of the program
+func Parse(data []byte) (*Program, error) {
+	program, length, err := parsePrefix(data)
+	if err != nil {
+		return nil, err
+	}
+	if length != len(data) {
+		return nil, fmt.Errorf("clvm: %d unexpected bytes after the end of the program", len(data)-length)
+	}
+	return program, nil
+}
+
+// ParseHex deserializes a hex encoded program, with or without the 0x prefix
+func ParseHex(hexStr string) (*Program, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(hexStr, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("clvm: invalid hex: %w", err)
+	}
+	return Parse(data)
+}
+
+// ParseSerializedProgram deserializes a program returned by the RPCs
+func ParseSerializedProgram(program types.SerializedProgram) (*Program, error) {
+	return ParseHex(string(program))
+}
+
+// parsePrefix deserializes the program at the start of data, and returns the number of bytes it used
+// The stack is kept on the heap so deeply nested programs can't overflow the goroutine stack
+func parsePrefix(data []byte) (*Program, int, error) {
+	pos := 0
+	var values []*Program
+	// ops is a stack of pending operations: true reads a program, false combines the two topmost values into a pair
+	ops := []bool{true}
+
+	for len(ops) > 0 {
+		read := ops[len(ops)-1]
+		ops = ops[:len(ops)-1]
+
+		if !read {
+			rest := values[len(values)-1]
+			first := values[len(values)-2]
+			values = append(values[:len(values)-2], Cons(first, rest))
+			continue
+		}
+
+		if pos >= len(data) {
+			return nil, 0, ErrTruncated
+		}
+		b := data[pos]
+		pos++
+
+		switch {
+		case b == consBoxMarker:
+			ops = append(ops, false, true, true)
+		case b == backReferenceMarker:
+			return nil, 0, errors.New("clvm: serialized programs with back references are not supported")
+		case b == 0x80:
+			values = append(values, Nil)
+		case b <= maxSingleByte:
+			values = append(values, Atom([]byte{b}))
+		default:
+			size, prefixLen, err := atomSize(b, data[pos:])
+			if err != nil {
+				return nil, 0, err
+			}
+			pos += prefixLen
+			if size > uint64(len(data)-pos) {
+				return nil, 0, ErrTruncated
+			}
+			atom := make([]byte, size)
+			copy(atom, data[pos:pos+int(size)])
+			pos += int(size)
+			values = append(values, Atom(atom))
+		}
+	}
+
+	return values[0], pos, nil
+}
+
+// atomSize decodes the size of an atom from the first byte and the bytes following it
+// The number of leading one bits in the first byte is the number of bytes used to encode the size
+// Returns the size and the number of bytes read from rest
+func atomSize(first byte, rest []byte) (uint64, int, error) {
+	prefixLen := 0
+	for mask := byte(0x80); first&mask != 0; mask >>= 1 {
+		first &^= mask
+		prefixLen++
+	}
+	if prefixLen > 5 {
+		return 0, 0, errors.New("clvm: invalid atom size prefix")
+	}
+	if prefixLen-1 > len(rest) {
+		return 0, 0, ErrTruncated
+	}
+
+	size := uint64(first)
+	for _, b := range rest[:prefixLen-1] {
+		size = size<<8 | uint64(b)
+	}
+	return size, prefixLen - 1, nil
+}
+
+// Bytes serializes the program
+func (p *Program) Bytes() []byte {
+	var buf bytes.Buffer
+	pending := []*Program{p}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if current.IsPair() {
+			buf.WriteByte(consBoxMarker)
+			pending = append(pending, current.rest, current.first)
+			continue
+		}
+		writeAtom(&buf, current.atom)
+	}
+	return buf.Bytes()
+}
+
+// writeAtom writes the atom with the shortest size prefix
+func writeAtom(buf *bytes.Buffer, atom []byte) {
+	size := len(atom)
+	switch {
+	case size == 0:
+		buf.WriteByte(0x80)
+		return
+	case size == 1 && atom[0] <= maxSingleByte:
+		buf.WriteByte(atom[0])
+		return
+	case size < 0x40:
+		buf.WriteByte(0x80 | byte(size))
+	case size < 0x2000:
+		buf.Write([]byte{0xc0 | byte(size>>8), byte(size)})
+	case size < 0x100000:
+		buf.Write([]byte{0xe0 | byte(size>>16), byte(size >> 8), byte(size)})
+	case size < 0x8000000:
+		buf.Write([]byte{0xf0 | byte(size>>24), byte(size >> 16), byte(size >> 8), byte(size)})
+	default:
+		buf.Write([]byte{0xf8 | byte(size>>32), byte(size >> 24), byte(size >> 16), byte(size >> 8), byte(size)})
+	}
+	buf.Write(atom)
+}
+
+// Hex serializes the program as 0x prefixed hex, the same way the RPCs return programs
+func (p *Program) Hex() string {
+	return "0x" + hex.EncodeToString(p.Bytes())
+}
+
+// SerializedProgram serializes the program for use in RPC requests and types
+func (p *Program) SerializedProgram() types.SerializedProgram {
+	return types.SerializedProgram(p.Hex())
+}
@@ -0,0 +1,338 @@
+package clvm
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/forks-lab/go-stai-libs/pkg/bls"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// CostPerByte is the cost of each byte of the spends in the transactions generator of a block
+const CostPerByte uint64 = 12000
+
+// DefaultMempoolMaxCost is the maximum cost of a spend bundle the mempool accepts, which is half the maximum cost of a block
+const DefaultMempoolMaxCost = DefaultMaxCost / 2
+
+// Sizes of the arguments of conditions, as checked by the full node
+const (
+	publicKeySize  = 48
+	hashSize       = 32
+	maxMessageSize = 1024
+)
+
+// SpendBundleAnalysis describes what a spend bundle does when it is pushed to a node
+type SpendBundleAnalysis struct {
+	Removals  []*types.Coin
+	Additions []*CoinAddition
+	// Fee is the total amount removed minus the total amount added. A negative fee means the bundle creates
+	// more value than it spends, and will be rejected
+	Fee *big.Int
+	// ReservedFee is the sum of the RESERVE_FEE conditions
+	ReservedFee uint64
+	Signatures  []*RequiredSignature
+
+	CoinAnnouncements           []*Announcement
+	PuzzleAnnouncements         []*Announcement
+	AssertedCoinAnnouncements   []types.Bytes32
+	AssertedPuzzleAnnouncements []types.Bytes32
+
+	// Cost is the cost of running all puzzles, including the cost of their conditions, plus SizeCost
+	Cost uint64
+	// SizeCost is CostPerByte for each byte of the generator that includes the spends in a block
+	SizeCost uint64
+	// Problems are the reasons the node would reject the bundle, as far as they can be found offline
+	Problems []string
+}
+
+// CoinAddition is a coin created by a spend
+type CoinAddition struct {
+	Coin *types.Coin
+	ID   types.Bytes32
+}
+
+// RequiredSignature is a signature the aggregated signature of the bundle must include
+type RequiredSignature struct {
+	Opcode    ConditionOpcode
+	PublicKey []byte
+	Message   []byte
+	// CoinID is the coin that returned the AGG_SIG_ME condition, which is appended to the message when signing
+	CoinID types.Bytes32
+}
+
+// SignedMessage returns the message that has to be signed
+// For AGG_SIG_ME, the coin ID and additionalData (the genesis challenge of the network) are appended to the message
+func (s *RequiredSignature) SignedMessage(additionalData types.Bytes32) []byte {
+	message := append([]byte{}, s.Message...)
+	if s.Opcode == ConditionAggSigMe {
+		message = append(message, s.CoinID[:]...)
+		message = append(message, additionalData[:]...)
+	}
+	return message
+}
+
+// VerifySignature checks the aggregated signature of the bundle includes every required signature
+// additionalData is the genesis challenge of the network, which is part of the AGG_SIG_ME messages
+func (a *SpendBundleAnalysis) VerifySignature(signature types.G2Element, additionalData types.Bytes32) error {
+	aggregated, err := bls.SignatureFromElement(signature)
+	if err != nil {
+		return err
+	}
+
+	pks := make([]*bls.PublicKey, 0, len(a.Signatures))
+	messages := make([][]byte, 0, len(a.Signatures))
+	for _, required := range a.Signatures {
+		pk, err := bls.PublicKeyFromBytes(required.PublicKey)
+		if err != nil {
+			return err
+		}
+		pks = append(pks, pk)
+		messages = append(messages, required.SignedMessage(additionalData))
+	}
+
+	if !bls.AggregateVerifyAugmented(pks, messages, aggregated) {
+		return fmt.Errorf("aggregated signature does not match the %d required signatures", len(pks))
+	}
+	return nil
+}
+
+// Announcement is an announcement created by a spend
+type Announcement struct {
+	// Origin is the coin ID for coin announcements, or the puzzle hash for puzzle announcements
+	Origin  types.Bytes32
+	Message []byte
+	// ID is the value used to assert the announcement, sha256(origin || message)
+	ID types.Bytes32
+}
+
+func newAnnouncement(origin types.Bytes32, message []byte) *Announcement {
+	h := sha256.New()
+	h.Write(origin[:])
+	h.Write(message)
+
+	announcement := &Announcement{Origin: origin, Message: message}
+	copy(announcement.ID[:], h.Sum(nil))
+	return announcement
+}
+
+// AnalyzeSpendBundle runs every coin solution in the bundle, and collects the coins, fee, signatures and announcements
+// Running the puzzles uses strict mode by default, the same way the mempool does. An error is returned if a spend
+// can't be run at all. Everything else the node would reject the bundle for is listed in Problems
+// The total cost is checked against options.MaxCost, or DefaultMempoolMaxCost when it is not set
+func AnalyzeSpendBundle(bundle *types.SpendBundle, options *RunOptions) (*SpendBundleAnalysis, error) {
+	if options == nil {
+		options = &RunOptions{Strict: true}
+	}
+
+	analysis := &SpendBundleAnalysis{Fee: new(big.Int)}
+	spent := map[types.Bytes32]bool{}
+	created := map[types.Bytes32]bool{}
+
+	for _, solution := range bundle.CoinSolutions {
+		if solution.Coin == nil {
+			return nil, fmt.Errorf("coin solution has no coin")
+		}
+		coinID, err := solution.Coin.ID()
+		if err != nil {
+			return nil, err
+		}
+
+		analysis.Removals = append(analysis.Removals, solution.Coin)
+		analysis.Fee.Add(analysis.Fee, solution.Coin.Amount.Big())
+		if spent[coinID] {
+			analysis.problemf("coin %s is spent more than once", coinID)
+		}
+		spent[coinID] = true
+
+		if err = CheckPuzzleReveal(solution); err != nil {
+			analysis.problemf("coin %s: %s", coinID, err)
+		}
+
+		result, err := RunCoinSolution(solution, *options)
+		if err != nil {
+			return nil, fmt.Errorf("running coin %s: %w", coinID, err)
+		}
+		analysis.Cost += result.TotalCost()
+
+		for _, condition := range result.Conditions {
+			if err = analysis.addCondition(solution.Coin, coinID, condition, created); err != nil {
+				analysis.problemf("coin %s: %s", coinID, err)
+			}
+		}
+	}
+
+	if analysis.Fee.Sign() < 0 {
+		analysis.problemf("negative fee of %s", analysis.Fee)
+	} else if analysis.Fee.Cmp(new(big.Int).SetUint64(analysis.ReservedFee)) < 0 {
+		analysis.problemf("fee of %s is less than the reserved fee of %d", analysis.Fee, analysis.ReservedFee)
+	}
+
+	analysis.checkAnnouncements()
+
+	generator, err := simpleGenerator(bundle)
+	if err != nil {
+		return nil, err
+	}
+	analysis.SizeCost = uint64(len(generator.Bytes())) * CostPerByte
+	analysis.Cost += analysis.SizeCost
+
+	maxCost := options.MaxCost
+	if maxCost == 0 {
+		maxCost = DefaultMempoolMaxCost
+	}
+	if analysis.Cost > maxCost {
+		analysis.problemf("cost of %d is more than the maximum of %d", analysis.Cost, maxCost)
+	}
+
+	return analysis, nil
+}
+
+// simpleGenerator returns the transactions generator a node creates for a block that only includes the bundle, which
+// quotes the list of (parent_id puzzle_reveal amount solution) spends
+func simpleGenerator(bundle *types.SpendBundle) (*Program, error) {
+	spends := make([]*Program, 0, len(bundle.CoinSolutions))
+	for _, solution := range bundle.CoinSolutions {
+		if solution.PuzzleReveal == nil || solution.Solution == nil {
+			return nil, fmt.Errorf("coin solution is missing the puzzle reveal or solution")
+		}
+		puzzle, err := ParseSerializedProgram(*solution.PuzzleReveal)
+		if err != nil {
+			return nil, err
+		}
+		args, err := ParseSerializedProgram(*solution.Solution)
+		if err != nil {
+			return nil, err
+		}
+		parent := solution.Coin.ParentCoinInfo
+		spends = append(spends, List(Atom(parent[:]), puzzle, Uint64(solution.Coin.Amount.Uint64()), args))
+	}
+	return Cons(Int64(quoteOpcode), List(List(spends...))), nil
+}
+
+func (a *SpendBundleAnalysis) problemf(format string, args ...interface{}) {
+	a.Problems = append(a.Problems, fmt.Sprintf(format, args...))
+}
+
+// addCondition records the effect of a single condition returned by the coin's puzzle
+func (a *SpendBundleAnalysis) addCondition(coin *types.Coin, coinID types.Bytes32, condition *Condition, created map[types.Bytes32]bool) error {
+	switch condition.Opcode {
+	case ConditionCreateCoin:
+		puzzleHash, err := condition.bytes32Arg(0)
+		if err != nil {
+			return err
+		}
+		if len(condition.Args) < 2 {
+			return fmt.Errorf("%s condition is missing the amount", condition.Opcode)
+		}
+		amount, err := condition.Args[1].AsUint64()
+		if err != nil {
+			return fmt.Errorf("%s condition has an invalid amount: %w", condition.Opcode, err)
+		}
+
+		addition := &types.Coin{
+			ParentCoinInfo: coinID,
+			PuzzleHash:     types.PuzzleHash(puzzleHash),
+			Amount:         types.Uint128From64(amount),
+		}
+		id, err := addition.ID()
+		if err != nil {
+			return err
+		}
+		a.Additions = append(a.Additions, &CoinAddition{Coin: addition, ID: id})
+		a.Fee.Sub(a.Fee, new(big.Int).SetUint64(amount))
+		if created[id] {
+			return fmt.Errorf("coin %s is created more than once", id)
+		}
+		created[id] = true
+
+	case ConditionReserveFee:
+		if len(condition.Args) < 1 {
+			return fmt.Errorf("%s condition is missing the amount", condition.Opcode)
+		}
+		fee, err := condition.Args[0].AsUint64()
+		if err != nil {
+			return fmt.Errorf("%s condition has an invalid amount: %w", condition.Opcode, err)
+		}
+		a.ReservedFee += fee
+
+	case ConditionAggSigMe, ConditionAggSigUnsafe:
+		publicKey, err := condition.Arg(0)
+		if err != nil {
+			return err
+		}
+		if len(publicKey) != publicKeySize {
+			return fmt.Errorf("%s condition has an invalid public key", condition.Opcode)
+		}
+		message, err := condition.Arg(1)
+		if err != nil {
+			return err
+		}
+		if len(message) > maxMessageSize {
+			return fmt.Errorf("%s condition message is too long", condition.Opcode)
+		}
+		a.Signatures = append(a.Signatures, &RequiredSignature{
+			Opcode:    condition.Opcode,
+			PublicKey: publicKey,
+			Message:   message,
+			CoinID:    coinID,
+		})
+
+	case ConditionCreateCoinAnnouncement, ConditionCreatePuzzleAnnouncement:
+		message, err := condition.Arg(0)
+		if err != nil {
+			return err
+		}
+		if len(message) > maxMessageSize {
+			return fmt.Errorf("%s condition message is too long", condition.Opcode)
+		}
+		if condition.Opcode == ConditionCreateCoinAnnouncement {
+			a.CoinAnnouncements = append(a.CoinAnnouncements, newAnnouncement(coinID, message))
+		} else {
+			a.PuzzleAnnouncements = append(a.PuzzleAnnouncements, newAnnouncement(types.Bytes32(coin.PuzzleHash), message))
+		}
+
+	case ConditionAssertCoinAnnouncement, ConditionAssertPuzzleAnnouncement:
+		id, err := condition.bytes32Arg(0)
+		if err != nil {
+			return err
+		}
+		if condition.Opcode == ConditionAssertCoinAnnouncement {
+			a.AssertedCoinAnnouncements = append(a.AssertedCoinAnnouncements, id)
+		} else {
+			a.AssertedPuzzleAnnouncements = append(a.AssertedPuzzleAnnouncements, id)
+		}
+	}
+	return nil
+}
+
+// checkAnnouncements adds a problem for every asserted announcement that is not created in the bundle
+func (a *SpendBundleAnalysis) checkAnnouncements() {
+	check := func(kind string, announcements []*Announcement, asserted []types.Bytes32) {
+		ids := map[types.Bytes32]bool{}
+		for _, announcement := range announcements {
+			ids[announcement.ID] = true
+		}
+		for _, id := range asserted {
+			if !ids[id] {
+				a.problemf("asserted %s announcement %s is not created in the bundle", kind, id)
+			}
+		}
+	}
+	check("coin", a.CoinAnnouncements, a.AssertedCoinAnnouncements)
+	check("puzzle", a.PuzzleAnnouncements, a.AssertedPuzzleAnnouncements)
+}
+
+// bytes32Arg returns the argument at index i, which must be 32 bytes
+func (c *Condition) bytes32Arg(i int) (types.Bytes32, error) {
+	atom, err := c.Arg(i)
+	if err != nil {
+		return types.Bytes32{}, err
+	}
+	if len(atom) != hashSize {
+		return types.Bytes32{}, fmt.Errorf("%s condition argument %d is not 32 bytes", c.Opcode, i)
+	}
+	var b types.Bytes32
+	copy(b[:], atom)
+	return b, nil
+}
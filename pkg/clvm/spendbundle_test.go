@@ -0,0 +1,139 @@
+package clvm_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/bls"
+	"github.com/forks-lab/go-stai-libs/pkg/clvm"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// coinSolution returns a spend of a coin locked to a puzzle that returns the conditions
+func coinSolution(parent byte, amount uint64, conditions ...*clvm.Program) *types.CoinSolution {
+	puzzle := quote(clvm.List(conditions...))
+	reveal := puzzle.SerializedProgram()
+	solution := clvm.Nil.SerializedProgram()
+	return &types.CoinSolution{
+		Coin: &types.Coin{
+			ParentCoinInfo: types.Bytes32{parent},
+			PuzzleHash:     types.PuzzleHash(puzzle.TreeHash()),
+			Amount:         types.Uint128From64(amount),
+		},
+		PuzzleReveal: &reveal,
+		Solution:     &solution,
+	}
+}
+
+func TestAnalyzeSpendBundle(t *testing.T) {
+	destination := make([]byte, 32)
+	publicKey := make([]byte, 48)
+
+	first := coinSolution(1, 1000,
+		clvm.List(clvm.Int64(51), clvm.Atom(destination), clvm.Int64(900)),
+		clvm.List(clvm.Int64(52), clvm.Int64(100)),
+		clvm.List(clvm.Int64(50), clvm.Atom(publicKey), clvm.Atom([]byte("hello"))),
+		clvm.List(clvm.Int64(60), clvm.Atom([]byte("announce"))),
+	)
+	firstID, err := first.Coin.ID()
+	assert.NoError(t, err)
+
+	analysis, err := clvm.AnalyzeSpendBundle(&types.SpendBundle{CoinSolutions: []*types.CoinSolution{first}}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, analysis.Problems)
+	assert.Len(t, analysis.Removals, 1)
+	assert.Equal(t, "100", analysis.Fee.String())
+	assert.Equal(t, uint64(100), analysis.ReservedFee)
+
+	if assert.Len(t, analysis.Additions, 1) {
+		addition := analysis.Additions[0]
+		assert.Equal(t, firstID, addition.Coin.ParentCoinInfo)
+		id, err := addition.Coin.ID()
+		assert.NoError(t, err)
+		assert.Equal(t, id, addition.ID)
+	}
+
+	if assert.Len(t, analysis.Signatures, 1) {
+		additionalData := types.Bytes32{0xcc}
+		message := analysis.Signatures[0].SignedMessage(additionalData)
+		assert.Equal(t, append(append([]byte("hello"), firstID[:]...), additionalData[:]...), message)
+	}
+
+	if assert.Len(t, analysis.CoinAnnouncements, 1) {
+		assert.Equal(t, firstID, analysis.CoinAnnouncements[0].Origin)
+	}
+
+	// The second coin asserts the announcement of the first, and one that is not created
+	second := coinSolution(2, 1000,
+		clvm.List(clvm.Int64(61), clvm.Atom(analysis.CoinAnnouncements[0].ID[:])),
+		clvm.List(clvm.Int64(61), clvm.Atom(make([]byte, 32))),
+		clvm.List(clvm.Int64(51), clvm.Atom(destination), clvm.Int64(500)),
+	)
+	bundle := &types.SpendBundle{CoinSolutions: []*types.CoinSolution{first, second, first}}
+	analysis, err = clvm.AnalyzeSpendBundle(bundle, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "700", analysis.Fee.String())
+	assert.Len(t, analysis.AssertedCoinAnnouncements, 2)
+	assert.Len(t, analysis.Problems, 3) // spent twice, created twice, one missing announcement
+
+	overspend := coinSolution(3, 10, clvm.List(clvm.Int64(51), clvm.Atom(destination), clvm.Int64(500)))
+	bundle = &types.SpendBundle{CoinSolutions: []*types.CoinSolution{overspend}}
+	analysis, err = clvm.AnalyzeSpendBundle(bundle, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "-490", analysis.Fee.String())
+	assert.Contains(t, analysis.Problems, "negative fee of -490")
+}
+
+func TestAnalyzeSpendBundleCost(t *testing.T) {
+	solution := coinSolution(1, 1000, clvm.List(clvm.Int64(1), clvm.Atom([]byte("remark"))))
+	analysis, err := clvm.AnalyzeSpendBundle(&types.SpendBundle{CoinSolutions: []*types.CoinSolution{solution}}, nil)
+	assert.NoError(t, err)
+
+	// The spends are included in a block as (q . ((parent puzzle amount solution)))
+	puzzle, err := clvm.ParseSerializedProgram(*solution.PuzzleReveal)
+	assert.NoError(t, err)
+	spend := clvm.List(clvm.Atom(solution.Coin.ParentCoinInfo[:]), puzzle, clvm.Int64(1000), clvm.Nil)
+	generator := quote(clvm.List(clvm.List(spend)))
+	assert.Equal(t, uint64(len(generator.Bytes()))*clvm.CostPerByte, analysis.SizeCost)
+
+	result, err := clvm.RunCoinSolution(solution, clvm.RunOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Equal(t, result.TotalCost()+analysis.SizeCost, analysis.Cost)
+	assert.Empty(t, analysis.Problems)
+
+	// A bundle that fits in a block can still be too large for the mempool
+	large := coinSolution(2, 1000, clvm.List(clvm.Int64(1), clvm.Atom(make([]byte, 500000))))
+	analysis, err = clvm.AnalyzeSpendBundle(&types.SpendBundle{CoinSolutions: []*types.CoinSolution{large}}, nil)
+	assert.NoError(t, err)
+	assert.Less(t, analysis.Cost, clvm.DefaultMaxCost)
+	assert.Greater(t, analysis.Cost, clvm.DefaultMempoolMaxCost)
+	assert.Len(t, analysis.Problems, 1)
+}
+
+func TestVerifySpendBundleSignature(t *testing.T) {
+	sk, err := bls.SecretKeyFromBytes(bytes.Repeat([]byte{0x11}, bls.SecretKeySize))
+	assert.NoError(t, err)
+	publicKey := sk.PublicKey().Bytes()
+	additionalData := types.Bytes32{0xcc}
+
+	solution := coinSolution(1, 1000,
+		clvm.List(clvm.Int64(50), clvm.Atom(publicKey), clvm.Atom([]byte("me"))),
+		clvm.List(clvm.Int64(49), clvm.Atom(publicKey), clvm.Atom([]byte("unsafe"))),
+	)
+	analysis, err := clvm.AnalyzeSpendBundle(&types.SpendBundle{CoinSolutions: []*types.CoinSolution{solution}}, nil)
+	assert.NoError(t, err)
+
+	var signatures []*bls.Signature
+	for _, required := range analysis.Signatures {
+		signature, err := sk.SignAugmented(required.SignedMessage(additionalData))
+		assert.NoError(t, err)
+		signatures = append(signatures, signature)
+	}
+	aggregated := bls.AggregateSignatures(signatures...).Element()
+
+	assert.NoError(t, analysis.VerifySignature(aggregated, additionalData))
+	assert.Error(t, analysis.VerifySignature(aggregated, types.Bytes32{}))
+	assert.Error(t, analysis.VerifySignature(signatures[0].Element(), additionalData))
+}
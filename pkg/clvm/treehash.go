@@ -0,0 +1,72 @@
+package clvm
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// TreeHash returns the sha256 tree hash of the program
+// Atoms hash as sha256(0x01 || atom) and pairs as sha256(0x02 || hash(first) || hash(rest)). The tree hash of a
+// puzzle is the puzzle hash coins are locked to
+func (p *Program) TreeHash() types.Bytes32 {
+	var hashes []types.Bytes32
+	// A nil program in the stack means the two topmost hashes should be combined into a pair hash
+	pending := []*Program{p}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if current == nil {
+			rest := hashes[len(hashes)-1]
+			first := hashes[len(hashes)-2]
+			hashes = append(hashes[:len(hashes)-2], hashPair(first, rest))
+			continue
+		}
+		if current.IsPair() {
+			pending = append(pending, nil, current.rest, current.first)
+			continue
+		}
+		hashes = append(hashes, hashAtom(current.atom))
+	}
+	return hashes[0]
+}
+
+func hashAtom(atom []byte) types.Bytes32 {
+	h := sha256.New()
+	h.Write([]byte{1})
+	h.Write(atom)
+
+	var hash types.Bytes32
+	copy(hash[:], h.Sum(nil))
+	return hash
+}
+
+func hashPair(first, rest types.Bytes32) types.Bytes32 {
+	h := sha256.New()
+	h.Write([]byte{2})
+	h.Write(first[:])
+	h.Write(rest[:])
+
+	var hash types.Bytes32
+	copy(hash[:], h.Sum(nil))
+	return hash
+}
+
+// CheckPuzzleReveal returns an error if the puzzle reveal of the coin solution does not hash to the puzzle hash of the coin
+func CheckPuzzleReveal(solution *types.CoinSolution) error {
+	if solution.Coin == nil || solution.PuzzleReveal == nil {
+		return fmt.Errorf("coin solution has no coin or puzzle reveal")
+	}
+	puzzle, err := ParseSerializedProgram(*solution.PuzzleReveal)
+	if err != nil {
+		return err
+	}
+
+	hash := types.PuzzleHash(puzzle.TreeHash())
+	if hash != solution.Coin.PuzzleHash {
+		return fmt.Errorf("puzzle reveal hashes to %s, but the coin puzzle hash is %s", hash, solution.Coin.PuzzleHash)
+	}
+	return nil
+}
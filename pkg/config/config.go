@@ -6,46 +6,257 @@ import (
 	"path/filepath"
 	"strings"
 
-	"gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/forks-lab/go-stai-libs/pkg/profile"
 )
 
 // StaiConfig the STAI config.yaml
+// Use Save to write changes back to config.yaml. Keys that are not part of this struct, and comments, are preserved
 type StaiConfig struct {
-	StaiRoot        string
-	DaemonPort      uint16          `yaml:"daemon_port"`
-	DaemonSSL       SSLConfig       `yaml:"daemon_ssl"`
-	Farmer          FarmerConfig    `yaml:"farmer"`
-	FullNode        FullNodeConfig  `yaml:"full_node"`
-	Harvester       HarvesterConfig `yaml:"harvester"`
-	Wallet          WalletConfig    `yaml:"wallet"`
-	Seeder          SeederConfig    `yaml:"seeder"`
-	SelectedNetwork string          `yaml:"selected_network"`
+	StaiRoot                 string                 `yaml:"-"`
+	MinMainnetKSize          uint8                  `yaml:"min_mainnet_k_size"`
+	PingInterval             uint16                 `yaml:"ping_interval"`
+	SelfHostname             string                 `yaml:"self_hostname"`
+	PreferIPv6               bool                   `yaml:"prefer_ipv6"`
+	RPCTimeout               uint16                 `yaml:"rpc_timeout"`
+	DaemonPort               uint16                 `yaml:"daemon_port"`
+	DaemonMaxMessageSize     uint32                 `yaml:"daemon_max_message_size"`
+	DaemonHeartbeat          uint16                 `yaml:"daemon_heartbeat"`
+	InboundRateLimitPercent  uint8                  `yaml:"inbound_rate_limit_percent"`
+	OutboundRateLimitPercent uint8                  `yaml:"outbound_rate_limit_percent"`
+	DaemonSSL                SSLConfig              `yaml:"daemon_ssl"`
+	PrivateSSLCA             CAConfig               `yaml:"private_ssl_ca"`
+	StaiSSLCA                CAConfig               `yaml:"stai_ssl_ca"`
+	Logging                  LoggingConfig          `yaml:"logging"`
+	Farmer                   FarmerConfig           `yaml:"farmer"`
+	FullNode                 FullNodeConfig         `yaml:"full_node"`
+	Harvester                HarvesterConfig        `yaml:"harvester"`
+	Wallet                   WalletConfig           `yaml:"wallet"`
+	Seeder                   SeederConfig           `yaml:"seeder"`
+	Pool                     PoolConfig             `yaml:"pool"`
+	Timelord                 TimelordConfig         `yaml:"timelord"`
+	TimelordLauncher         TimelordLauncherConfig `yaml:"timelord_launcher"`
+	Introducer               IntroducerConfig       `yaml:"introducer"`
+	SelectedNetwork          string                 `yaml:"selected_network"`
+	NetworkOverrides         NetworkOverrides       `yaml:"network_overrides"`
+
+	// profile is the chain the config belongs to
+	profile *profile.Profile
+
+	// configPath is the file the config was loaded from
+	configPath string
+	// node is the parsed yaml document, including keys that aren't part of the struct and comments
+	node *yaml.Node
+	// rawDatabasePath is the full node database_path before CHALLENGE was replaced
+	rawDatabasePath string
+	// loadedDatabasePath is the full node database_path after CHALLENGE was replaced
+	loadedDatabasePath string
+}
+
+// NetworkOverrides per network constants and config settings, keyed by network name
+type NetworkOverrides struct {
+	Constants map[string]NetworkConstants `yaml:"constants"`
+	Config    map[string]NetworkConfig    `yaml:"config"`
+}
+
+// NetworkConstants consensus constants that are overridden for a network
+// Values that are not overridden are left as zero values
+type NetworkConstants struct {
+	GenesisChallenge               string `yaml:"GENESIS_CHALLENGE"`
+	GenesisPreFarmPoolPuzzleHash   string `yaml:"GENESIS_PRE_FARM_POOL_PUZZLE_HASH"`
+	GenesisPreFarmFarmerPuzzleHash string `yaml:"GENESIS_PRE_FARM_FARMER_PUZZLE_HASH"`
+	MinPlotSize                    uint8  `yaml:"MIN_PLOT_SIZE"`
+	NetworkType                    uint8  `yaml:"NETWORK_TYPE"`
+	DifficultyConstantFactor       uint64 `yaml:"DIFFICULTY_CONSTANT_FACTOR"`
+	DifficultyStarting             uint64 `yaml:"DIFFICULTY_STARTING"`
+	EpochBlocks                    uint32 `yaml:"EPOCH_BLOCKS"`
+	SubSlotItersStarting           uint64 `yaml:"SUB_SLOT_ITERS_STARTING"`
+	MempoolBlockBuffer             uint32 `yaml:"MEMPOOL_BLOCK_BUFFER"`
+
+	// Other contains any overridden constants that don't have a field above
+	Other map[string]interface{} `yaml:",inline"`
+}
+
+// NetworkConfig the config settings for a single network
+type NetworkConfig struct {
+	AddressPrefix       string `yaml:"address_prefix"`
+	DefaultFullNodePort uint16 `yaml:"default_full_node_port"`
+
+	// Other contains any settings that don't have a field above
+	Other map[string]interface{} `yaml:",inline"`
+}
+
+// Network the resolved constants and config for a single network
+type Network struct {
+	Name      string
+	Constants NetworkConstants
+	Config    NetworkConfig
 }
 
 // FarmerConfig farmer configuration section
 type FarmerConfig struct {
-	PortConfig `yaml:",inline"`
-	SSL        SSLConfig `yaml:"ssl"`
+	PortConfig         `yaml:",inline"`
+	FullNodePeer       PeerConfig         `yaml:"full_node_peer"`
+	PoolPublicKeys     StringSet          `yaml:"pool_public_keys"`
+	XCHTargetAddress   string             `yaml:"xch_target_address"`
+	PoolShareThreshold uint32             `yaml:"pool_share_threshold"`
+	PoolList           []FarmerPoolConfig `yaml:"pool_list"`
+	StartRPCServer     bool               `yaml:"start_rpc_server"`
+	Logging            LoggingConfig      `yaml:"logging"`
+	SelectedNetwork    string             `yaml:"selected_network"`
+	SSL                SSLConfig          `yaml:"ssl"`
+}
+
+// StringSet a yaml !!set of strings, such as the farmer pool_public_keys
+// A plain list is also accepted when unmarshaling. It is always written back as a !!set
+type StringSet []string
+
+// UnmarshalYAML collects the keys of a !!set or mapping, or the items of a sequence
+func (s *StringSet) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.MappingNode:
+		set := make(StringSet, 0, len(value.Content)/2)
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			set = append(set, value.Content[i].Value)
+		}
+		*s = set
+		return nil
+	case yaml.SequenceNode:
+		var list []string
+		if err := value.Decode(&list); err != nil {
+			return err
+		}
+		*s = list
+		return nil
+	case yaml.ScalarNode:
+		if value.Tag == "!!null" {
+			*s = nil
+			return nil
+		}
+	}
+	return fmt.Errorf("line %d: cannot unmarshal %s into a set of strings", value.Line, value.ShortTag())
+}
+
+// MarshalYAML writes the strings as the keys of a !!set
+func (s StringSet) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!set"}
+	if len(s) == 0 {
+		node.Style = yaml.FlowStyle
+	}
+	for _, item := range s {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"},
+		)
+	}
+	return node, nil
+}
+
+// FarmerPoolConfig a single plot NFT in the farmer pool_list
+type FarmerPoolConfig struct {
+	LauncherID              string `yaml:"launcher_id"`
+	AuthenticationPublicKey string `yaml:"authentication_public_key"`
+	OwnerPublicKey          string `yaml:"owner_public_key"`
+	P2SingletonPuzzleHash   string `yaml:"p2_singleton_puzzle_hash"`
+	PayoutInstructions      string `yaml:"payout_instructions"`
+	PoolURL                 string `yaml:"pool_url"`
+	TargetPuzzleHash        string `yaml:"target_puzzle_hash"`
+}
+
+// PoolConfig pool configuration section
+type PoolConfig struct {
+	XCHTargetAddress string        `yaml:"xch_target_address"`
+	Logging          LoggingConfig `yaml:"logging"`
+	SelectedNetwork  string        `yaml:"selected_network"`
 }
 
 // FullNodeConfig full node configuration section
 type FullNodeConfig struct {
-	PortConfig      `yaml:",inline"`
-	SSL             SSLConfig `yaml:"ssl"`
-	SelectedNetwork string    `yaml:"selected_network"`
-	DatabasePath    string    `yaml:"database_path"`
+	PortConfig                     `yaml:",inline"`
+	DBSync                         string        `yaml:"db_sync"`
+	DBReaders                      uint8         `yaml:"db_readers"`
+	DatabasePath                   string        `yaml:"database_path"`
+	PeerDBPath                     string        `yaml:"peer_db_path"`
+	PeersFilePath                  string        `yaml:"peers_file_path"`
+	StartRPCServer                 bool          `yaml:"start_rpc_server"`
+	EnableUPnP                     bool          `yaml:"enable_upnp"`
+	SyncBlocksBehindThreshold      uint32        `yaml:"sync_blocks_behind_threshold"`
+	ShortSyncBlocksBehindThreshold uint32        `yaml:"short_sync_blocks_behind_threshold"`
+	ReservedCores                  uint8         `yaml:"reserved_cores"`
+	SingleThreaded                 bool          `yaml:"single_threaded"`
+	PeerConnectInterval            uint16        `yaml:"peer_connect_interval"`
+	PeerConnectTimeout             uint16        `yaml:"peer_connect_timeout"`
+	TargetPeerCount                uint16        `yaml:"target_peer_count"`
+	TargetOutboundPeerCount        uint16        `yaml:"target_outbound_peer_count"`
+	ExemptPeerNetworks             []string      `yaml:"exempt_peer_networks"`
+	MaxInboundWallet               uint16        `yaml:"max_inbound_wallet"`
+	MaxInboundFarmer               uint16        `yaml:"max_inbound_farmer"`
+	MaxInboundTimelord             uint16        `yaml:"max_inbound_timelord"`
+	RecentPeerThreshold            uint32        `yaml:"recent_peer_threshold"`
+	SendUncompactInterval          uint32        `yaml:"send_uncompact_interval"`
+	TargetUncompactProofs          uint32        `yaml:"target_uncompact_proofs"`
+	SanitizeWeightProofOnly        bool          `yaml:"sanitize_weight_proof_only"`
+	WeightProofTimeout             uint16        `yaml:"weight_proof_timeout"`
+	MaxSyncWait                    uint16        `yaml:"max_sync_wait"`
+	EnableProfiler                 bool          `yaml:"enable_profiler"`
+	LogSqliteCmds                  bool          `yaml:"log_sqlite_cmds"`
+	MaxSubscribeItems              uint32        `yaml:"max_subscribe_items"`
+	TrustedMaxSubscribeItems       uint32        `yaml:"trusted_max_subscribe_items"`
+	IntroducerPeer                 PeerConfig    `yaml:"introducer_peer"`
+	WalletPeer                     PeerConfig    `yaml:"wallet_peer"`
+	Logging                        LoggingConfig `yaml:"logging"`
+	SelectedNetwork                string        `yaml:"selected_network"`
+	SSL                            SSLConfig     `yaml:"ssl"`
 }
 
 // HarvesterConfig harvester configuration section
 type HarvesterConfig struct {
-	PortConfig `yaml:",inline"`
-	SSL        SSLConfig `yaml:"ssl"`
+	PortConfig            `yaml:",inline"`
+	FarmerPeer            PeerConfig                  `yaml:"farmer_peer"`
+	StartRPCServer        bool                        `yaml:"start_rpc_server"`
+	NumThreads            uint8                       `yaml:"num_threads"`
+	PlotsRefreshParameter PlotsRefreshParameterConfig `yaml:"plots_refresh_parameter"`
+	ParallelRead          bool                        `yaml:"parallel_read"`
+	PlotDirectories       []string                    `yaml:"plot_directories"`
+	RecursivePlotScan     bool                        `yaml:"recursive_plot_scan"`
+	Logging               LoggingConfig               `yaml:"logging"`
+	SelectedNetwork       string                      `yaml:"selected_network"`
+	SSL                   SSLConfig                   `yaml:"ssl"`
+}
+
+// PlotsRefreshParameterConfig settings for how often the harvester scans for plots
+type PlotsRefreshParameterConfig struct {
+	IntervalSeconds     uint32 `yaml:"interval_seconds"`
+	RetryInvalidSeconds uint32 `yaml:"retry_invalid_seconds"`
+	BatchSize           uint32 `yaml:"batch_size"`
+	BatchSleepMS        uint32 `yaml:"batch_sleep_milliseconds"`
 }
 
 // WalletConfig wallet configuration section
 type WalletConfig struct {
-	PortConfig `yaml:",inline"`
-	SSL        SSLConfig `yaml:"ssl"`
+	PortConfig                     `yaml:",inline"`
+	EnableProfiler                 bool            `yaml:"enable_profiler"`
+	DBSync                         string          `yaml:"db_sync"`
+	DBReaders                      uint8           `yaml:"db_readers"`
+	ConnectToUnknownPeers          bool            `yaml:"connect_to_unknown_peers"`
+	InitialNumPublicKeys           uint16          `yaml:"initial_num_public_keys"`
+	ReusePublicKeyForChange        map[string]bool `yaml:"reuse_public_key_for_change"`
+	DNSServers                     []string        `yaml:"dns_servers"`
+	FullNodePeer                   PeerConfig      `yaml:"full_node_peer"`
+	DatabasePath                   string          `yaml:"database_path"`
+	WalletPeersPath                string          `yaml:"wallet_peers_path"`
+	TargetPeerCount                uint16          `yaml:"target_peer_count"`
+	PeerConnectInterval            uint16          `yaml:"peer_connect_interval"`
+	RecentPeerThreshold            uint32          `yaml:"recent_peer_threshold"`
+	IntroducerPeer                 PeerConfig      `yaml:"introducer_peer"`
+	ShortSyncBlocksBehindThreshold uint32          `yaml:"short_sync_blocks_behind_threshold"`
+	AutomaticallyAddUnknownCATs    bool            `yaml:"automatically_add_unknown_cats"`
+	TxResendTimeoutSecs            uint32          `yaml:"tx_resend_timeout_secs"`
+	StartRPCServer                 bool            `yaml:"start_rpc_server"`
+	Logging                        LoggingConfig   `yaml:"logging"`
+	SelectedNetwork                string          `yaml:"selected_network"`
+	SSL                            SSLConfig       `yaml:"ssl"`
 }
 
 // SeederConfig seeder configuration section
@@ -59,12 +270,61 @@ type CrawlerConfig struct {
 	SSL        SSLConfig `yaml:"ssl"`
 }
 
+// TimelordConfig timelord configuration section
+type TimelordConfig struct {
+	PortConfig                 `yaml:",inline"`
+	FullNodePeer               PeerConfig       `yaml:"full_node_peer"`
+	VDFClients                 VDFClientsConfig `yaml:"vdf_clients"`
+	VDFServer                  PeerConfig       `yaml:"vdf_server"`
+	MaxConnectionTime          uint16           `yaml:"max_connection_time"`
+	FastAlgorithm              bool             `yaml:"fast_algorithm"`
+	BlueboxMode                bool             `yaml:"bluebox_mode"`
+	SlowBluebox                bool             `yaml:"slow_bluebox"`
+	SlowBlueboxProcessCount    uint8            `yaml:"slow_bluebox_process_count"`
+	MultiprocessingStartMethod string           `yaml:"multiprocessing_start_method"`
+	StartRPCServer             bool             `yaml:"start_rpc_server"`
+	Logging                    LoggingConfig    `yaml:"logging"`
+	SelectedNetwork            string           `yaml:"selected_network"`
+	SSL                        SSLConfig        `yaml:"ssl"`
+}
+
+// VDFClientsConfig the VDF clients the timelord accepts connections from
+type VDFClientsConfig struct {
+	IP          []string `yaml:"ip"`
+	IPsEstimate []uint32 `yaml:"ips_estimate"`
+}
+
+// TimelordLauncherConfig timelord launcher configuration section
+type TimelordLauncherConfig struct {
+	Host         string        `yaml:"host"`
+	Port         uint16        `yaml:"port"`
+	ProcessCount uint8         `yaml:"process_count"`
+	Logging      LoggingConfig `yaml:"logging"`
+}
+
+// IntroducerConfig introducer configuration section
+type IntroducerConfig struct {
+	Host                string        `yaml:"host"`
+	Port                uint16        `yaml:"port"`
+	MaxPeersToSend      uint16        `yaml:"max_peers_to_send"`
+	RecentPeerThreshold uint32        `yaml:"recent_peer_threshold"`
+	Logging             LoggingConfig `yaml:"logging"`
+	SelectedNetwork     string        `yaml:"selected_network"`
+	SSL                 SSLConfig     `yaml:"ssl"`
+}
+
 // PortConfig common port settings found in many sections of the config
 type PortConfig struct {
 	Port    uint16 `yaml:"port"`
 	RPCPort uint16 `yaml:"rpc_port"`
 }
 
+// PeerConfig the host and port of a peer to connect to
+type PeerConfig struct {
+	Host string `yaml:"host"`
+	Port uint16 `yaml:"port"`
+}
+
 // SSLConfig common ssl settings found in many sections of the config
 type SSLConfig struct {
 	PrivateCRT string `yaml:"private_crt"`
@@ -73,15 +333,50 @@ type SSLConfig struct {
 	PublicKey  string `yaml:"public_key"`
 }
 
+// CAConfig the paths to a certificate authority
+type CAConfig struct {
+	Crt string `yaml:"crt"`
+	Key string `yaml:"key"`
+}
+
+// LoggingConfig logging settings found in many sections of the config
+type LoggingConfig struct {
+	LogStdout           bool   `yaml:"log_stdout"`
+	LogFilename         string `yaml:"log_filename"`
+	LogLevel            string `yaml:"log_level"`
+	LogMaxFilesRotation uint16 `yaml:"log_maxfilesrotation"`
+	LogMaxBytesRotation uint64 `yaml:"log_maxbytesrotation"`
+	LogUseGzip          bool   `yaml:"log_use_gzip"`
+	LogSyslog           bool   `yaml:"log_syslog"`
+	LogSyslogHost       string `yaml:"log_syslog_host"`
+	LogSyslogPort       uint16 `yaml:"log_syslog_port"`
+}
+
 // GetStaiConfig returns a struct containing the config.yaml values
 func GetStaiConfig() (*StaiConfig, error) {
-	rootPath, err := GetStaiRootPath()
+	return GetConfigForProfile(profile.Default)
+}
+
+// GetConfigForProfile returns a struct containing the config.yaml values for the installation described by p
+func GetConfigForProfile(p *profile.Profile) (*StaiConfig, error) {
+	rootPath, err := p.RootPath()
 	if err != nil {
 		return nil, err
 	}
 
+	cfg, err := LoadStaiConfig(rootPath)
+	if err != nil {
+		return nil, err
+	}
+	cfg.profile = p
+
+	return cfg, nil
+}
+
+// LoadStaiConfig loads config.yaml from the STAI root at rootPath
+func LoadStaiConfig(rootPath string) (*StaiConfig, error) {
 	configPath := filepath.Join(rootPath, "config", "config.yaml")
-	if _, err = os.Stat(configPath); os.IsNotExist(err) {
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("config file not found")
 	}
 
@@ -90,33 +385,39 @@ func GetStaiConfig() (*StaiConfig, error) {
 		return nil, err
 	}
 
-	config := &StaiConfig{}
+	node := &yaml.Node{}
+	err = yaml.Unmarshal(configBytes, node)
+	if err != nil {
+		return nil, err
+	}
 
-	err = yaml.Unmarshal(configBytes, config)
+	config := &StaiConfig{}
+	err = node.Decode(config)
 	if err != nil {
 		return nil, err
 	}
 
 	config.StaiRoot = rootPath
+	config.configPath = configPath
+	config.node = node
 	config.fillDatabasePath()
 
 	return config, nil
 }
 
-// GetStaiRootPath returns the root path for the STAI installation
+// GetStaiRootPath returns the root path for the installation of the default profile
+// For STAI, this is STAI_ROOT if set, otherwise ~/.stai/mainnet
 func GetStaiRootPath() (string, error) {
-	if root, ok := os.LookupEnv("STAI_ROOT"); ok {
-		return root, nil
-	}
+	return profile.Default.RootPath()
+}
 
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
+// Profile returns the profile of the chain the config belongs to
+// Configs loaded without a profile use the default profile
+func (c *StaiConfig) Profile() *profile.Profile {
+	if c.profile == nil {
+		return profile.Default
 	}
-
-	root := filepath.Join(home, ".stai", "mainnet")
-
-	return root, nil
+	return c.profile
 }
 
 // GetFullPath returns the full path to a particular filename within STAI_ROOT
@@ -125,5 +426,49 @@ func (c *StaiConfig) GetFullPath(filename string) string {
 }
 
 func (c *StaiConfig) fillDatabasePath() {
+	c.rawDatabasePath = c.FullNode.DatabasePath
 	c.FullNode.DatabasePath = strings.Replace(c.FullNode.DatabasePath, "CHALLENGE", c.FullNode.SelectedNetwork, 1)
+	c.loadedDatabasePath = c.FullNode.DatabasePath
+}
+
+// SelectedNetworkName returns the name of the selected network
+// The top level selected_network is used, falling back to the full node's selected_network
+func (c *StaiConfig) SelectedNetworkName() string {
+	if c.SelectedNetwork != "" {
+		return c.SelectedNetwork
+	}
+	return c.FullNode.SelectedNetwork
+}
+
+// ActiveNetwork returns the constants and config from network_overrides for the selected network
+func (c *StaiConfig) ActiveNetwork() (*Network, error) {
+	name := c.SelectedNetworkName()
+	if name == "" {
+		return nil, fmt.Errorf("no network is selected in the config")
+	}
+
+	constants, hasConstants := c.NetworkOverrides.Constants[name]
+	networkConfig, hasConfig := c.NetworkOverrides.Config[name]
+	if !hasConstants && !hasConfig {
+		return nil, fmt.Errorf("network %s is not present in network_overrides", name)
+	}
+
+	return &Network{
+		Name:      name,
+		Constants: constants,
+		Config:    networkConfig,
+	}, nil
+}
+
+// AddressPrefix returns the address prefix for the selected network
+func (c *StaiConfig) AddressPrefix() (string, error) {
+	network, err := c.ActiveNetwork()
+	if err != nil {
+		return "", err
+	}
+	if network.Config.AddressPrefix == "" {
+		return "", fmt.Errorf("no address prefix is configured for network %s", network.Name)
+	}
+
+	return network.Config.AddressPrefix, nil
 }
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Save writes the config back to the config.yaml it was loaded from
+// Changed values are merged into the original document, so keys that are not part of StaiConfig, comments,
+// and anchors/aliases whose values did not change are preserved. Keys are never removed from the file.
+func (c *StaiConfig) Save() error {
+	configPath := c.configPath
+	if configPath == "" {
+		configPath = c.GetFullPath(filepath.Join("config", "config.yaml"))
+	}
+
+	data, err := c.marshal()
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(configPath, data)
+}
+
+// marshal returns the yaml document for the config, merged into the original document if there is one
+func (c *StaiConfig) marshal() ([]byte, error) {
+	toSave := *c
+	if c.rawDatabasePath != "" && toSave.FullNode.DatabasePath == c.loadedDatabasePath {
+		toSave.FullNode.DatabasePath = c.rawDatabasePath
+	}
+
+	updated := &yaml.Node{}
+	err := updated.Encode(&toSave)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := updated
+	if c.node != nil && len(c.node.Content) > 0 {
+		mergeNode(c.node.Content[0], updated)
+		doc = c.node
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	err = encoder.Encode(doc)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// mergeNode updates dst in place so that it decodes to the same value as src, touching as little of dst as possible
+func mergeNode(dst, src *yaml.Node) {
+	// Sets are merged as a whole, since their keys have null values that would otherwise be skipped as zero values,
+	// and removed keys would be kept
+	if dst.Tag == "!!set" || src.Tag == "!!set" {
+		if !setsEqual(dst, src) {
+			replaceNode(dst, src)
+		}
+		return
+	}
+
+	if dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(src.Content); i += 2 {
+			key, value := src.Content[i], src.Content[i+1]
+			if existing := mappingValue(dst, key.Value); existing != nil {
+				mergeNode(existing, value)
+			} else if !isZeroNode(value) {
+				dst.Content = append(dst.Content, key, value)
+			}
+		}
+		return
+	}
+
+	if nodesEqual(dst, src) {
+		return
+	}
+
+	if dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode && len(dst.Content) == len(src.Content) {
+		for i := range src.Content {
+			mergeNode(dst.Content[i], src.Content[i])
+		}
+		return
+	}
+
+	replaceNode(dst, src)
+}
+
+// setsEqual reports whether both nodes are sets or mappings with the same keys, in any order
+func setsEqual(original, updated *yaml.Node) bool {
+	if original.Kind != yaml.MappingNode || updated.Kind != yaml.MappingNode || len(original.Content) != len(updated.Content) {
+		return false
+	}
+	for i := 0; i+1 < len(updated.Content); i += 2 {
+		if mappingValue(original, updated.Content[i].Value) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil if the key is not present
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// replaceNode replaces the value of dst with src, keeping the comments attached to dst
+func replaceNode(dst, src *yaml.Node) {
+	head, line, foot := dst.HeadComment, dst.LineComment, dst.FootComment
+	*dst = *src
+	dst.HeadComment, dst.LineComment, dst.FootComment = head, line, foot
+}
+
+// nodesEqual reports whether the nodes decode to the same value
+// A null or missing key in the original document is equal to the zero value that would be written in its place
+func nodesEqual(original, updated *yaml.Node) bool {
+	if original.Kind == yaml.ScalarNode && updated.Kind == yaml.ScalarNode && original.Value == updated.Value &&
+		original.Tag != "!!null" {
+		return true
+	}
+
+	var originalValue, updatedValue interface{}
+	if original.Decode(&originalValue) != nil || updated.Decode(&updatedValue) != nil {
+		return false
+	}
+
+	return valuesEqual(originalValue, updatedValue)
+}
+
+// valuesEqual compares decoded yaml values
+// Keys only present in the original are ignored, since they are never removed when saving
+func valuesEqual(original, updated interface{}) bool {
+	if original == nil {
+		return isZeroValue(updated)
+	}
+
+	switch originalValue := original.(type) {
+	case map[string]interface{}:
+		updatedValue, ok := updated.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range updatedValue {
+			if !valuesEqual(originalValue[key], value) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		updatedValue, ok := updated.([]interface{})
+		if !ok || len(originalValue) != len(updatedValue) {
+			return false
+		}
+		for i := range originalValue {
+			if !valuesEqual(originalValue[i], updatedValue[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(original, updated)
+	}
+}
+
+func isZeroNode(node *yaml.Node) bool {
+	var value interface{}
+	if node.Decode(&value) != nil {
+		return false
+	}
+	return isZeroValue(value)
+}
+
+func isZeroValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice:
+		if v.Len() == 0 {
+			return true
+		}
+		if v.Kind() == reflect.Map {
+			for _, key := range v.MapKeys() {
+				if !isZeroValue(v.MapIndex(key).Interface()) {
+					return false
+				}
+			}
+			return true
+		}
+		return false
+	default:
+		return v.IsZero()
+	}
+}
+
+// writeFileAtomic writes to a temporary file in the same directory and renames it over path
+// so readers never see a partially written config
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	err = tmp.Chmod(mode)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,130 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/config"
+)
+
+const testConfig = `# STAI config
+min_mainnet_k_size: 32
+self_hostname: &self_hostname localhost
+some_future_key: keep me # unknown keys survive
+daemon_port: 55400
+logging: &logging
+  log_stdout: false
+  log_level: WARNING
+farmer:
+  # the farmer talks to the local full node
+  full_node_peer:
+    host: *self_hostname
+    port: 1999
+  pool_public_keys: !!set {}
+  xch_target_address: stai1old
+  logging: *logging
+  port: 1999
+  rpc_port: 1559
+harvester:
+  plot_directories:
+  - /plots/one
+  rpc_port: 1560
+full_node:
+  database_path: db/blockchain_v2_CHALLENGE.sqlite
+  selected_network: mainnet
+  target_peer_count: 80
+`
+
+func writeTestConfig(t *testing.T) string {
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "config"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "config", "config.yaml"), []byte(testConfig), 0600))
+	return root
+}
+
+func TestLoadStaiConfig(t *testing.T) {
+	cfg, err := config.LoadStaiConfig(writeTestConfig(t))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "localhost", cfg.Farmer.FullNodePeer.Host)
+	assert.Equal(t, "WARNING", cfg.Farmer.Logging.LogLevel)
+	assert.Empty(t, cfg.Farmer.PoolPublicKeys)
+	assert.Equal(t, []string{"/plots/one"}, cfg.Harvester.PlotDirectories)
+	assert.Equal(t, "db/blockchain_v2_mainnet.sqlite", cfg.FullNode.DatabasePath)
+}
+
+func TestSavePreservesDocument(t *testing.T) {
+	root := writeTestConfig(t)
+	cfg, err := config.LoadStaiConfig(root)
+	assert.NoError(t, err)
+
+	cfg.Farmer.XCHTargetAddress = "stai1new"
+	cfg.Harvester.PlotDirectories = append(cfg.Harvester.PlotDirectories, "/plots/two")
+	cfg.FullNode.TargetPeerCount = 40
+	assert.NoError(t, cfg.Save())
+
+	saved, err := os.ReadFile(filepath.Join(root, "config", "config.yaml"))
+	assert.NoError(t, err)
+	savedString := string(saved)
+
+	assert.Contains(t, savedString, "# STAI config")
+	assert.Contains(t, savedString, "# the farmer talks to the local full node")
+	assert.Contains(t, savedString, "some_future_key: keep me # unknown keys survive")
+	assert.Contains(t, savedString, "host: *self_hostname")
+	assert.Contains(t, savedString, "logging: *logging")
+	assert.Contains(t, savedString, "database_path: db/blockchain_v2_CHALLENGE.sqlite")
+	assert.Contains(t, savedString, "pool_public_keys: !!set {}")
+	assert.NotContains(t, savedString, "timelord", "zero value sections should not be added")
+
+	info, err := os.Stat(filepath.Join(root, "config", "config.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	reloaded, err := config.LoadStaiConfig(root)
+	assert.NoError(t, err)
+	assert.Equal(t, "stai1new", reloaded.Farmer.XCHTargetAddress)
+	assert.Equal(t, []string{"/plots/one", "/plots/two"}, reloaded.Harvester.PlotDirectories)
+	assert.Equal(t, uint16(40), reloaded.FullNode.TargetPeerCount)
+	assert.Equal(t, "db/blockchain_v2_mainnet.sqlite", reloaded.FullNode.DatabasePath)
+}
+
+func TestSaveReplacesChangedAlias(t *testing.T) {
+	root := writeTestConfig(t)
+	cfg, err := config.LoadStaiConfig(root)
+	assert.NoError(t, err)
+
+	cfg.Farmer.FullNodePeer.Host = "10.0.0.2"
+	assert.NoError(t, cfg.Save())
+
+	reloaded, err := config.LoadStaiConfig(root)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.2", reloaded.Farmer.FullNodePeer.Host)
+	assert.Equal(t, "localhost", reloaded.SelfHostname)
+}
+
+func TestSavePoolPublicKeys(t *testing.T) {
+	root := writeTestConfig(t)
+	cfg, err := config.LoadStaiConfig(root)
+	assert.NoError(t, err)
+
+	cfg.Farmer.PoolPublicKeys = config.StringSet{"a1f0", "b2f0"}
+	assert.NoError(t, cfg.Save())
+
+	saved, err := os.ReadFile(filepath.Join(root, "config", "config.yaml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(saved), "pool_public_keys: !!set\n    a1f0: null\n    b2f0: null\n")
+
+	reloaded, err := config.LoadStaiConfig(root)
+	assert.NoError(t, err)
+	assert.Equal(t, config.StringSet{"a1f0", "b2f0"}, reloaded.Farmer.PoolPublicKeys)
+
+	reloaded.Farmer.PoolPublicKeys = config.StringSet{"b2f0"}
+	assert.NoError(t, reloaded.Save())
+
+	reloaded, err = config.LoadStaiConfig(root)
+	assert.NoError(t, err)
+	assert.Equal(t, config.StringSet{"b2f0"}, reloaded.Farmer.PoolPublicKeys)
+}
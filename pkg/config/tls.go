@@ -8,7 +8,13 @@ import (
 
 // LoadPrivateKeyPair loads the private key pair for the SSLConfig
 func (s *SSLConfig) LoadPrivateKeyPair() (*tls.Certificate, error) {
-	rootPath, err := GetStaiRootPath()
+	return s.LoadPrivateKeyPairFromRoot("")
+}
+
+// LoadPrivateKeyPairFromRoot loads the private key pair for the SSLConfig relative to rootPath
+// If rootPath is empty, the root path of the default profile is used
+func (s *SSLConfig) LoadPrivateKeyPairFromRoot(rootPath string) (*tls.Certificate, error) {
+	rootPath, err := rootPathOrDefault(rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -23,7 +29,13 @@ func (s *SSLConfig) LoadPrivateKeyPair() (*tls.Certificate, error) {
 
 // LoadPublicKeyPair loads the public key pair for the SSLConfig
 func (s *SSLConfig) LoadPublicKeyPair() (*tls.Certificate, error) {
-	rootPath, err := GetStaiRootPath()
+	return s.LoadPublicKeyPairFromRoot("")
+}
+
+// LoadPublicKeyPairFromRoot loads the public key pair for the SSLConfig relative to rootPath
+// If rootPath is empty, the root path of the default profile is used
+func (s *SSLConfig) LoadPublicKeyPairFromRoot(rootPath string) (*tls.Certificate, error) {
+	rootPath, err := rootPathOrDefault(rootPath)
 	if err != nil {
 		return nil, err
 	}
@@ -35,3 +47,10 @@ func (s *SSLConfig) LoadPublicKeyPair() (*tls.Certificate, error) {
 	pair, err := tls.LoadX509KeyPair(filepath.Join(rootPath, s.PublicCRT), filepath.Join(rootPath, s.PublicKey))
 	return &pair, err
 }
+
+func rootPathOrDefault(rootPath string) (string, error) {
+	if rootPath != "" {
+		return rootPath, nil
+	}
+	return GetStaiRootPath()
+}
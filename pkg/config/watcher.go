@@ -0,0 +1,191 @@
+package config
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ReloadHandler is called with the freshly loaded config after config.yaml or one of the SSL files it references changes
+// If the handler returns an error, the change is retried on the next poll
+type ReloadHandler func(cfg *StaiConfig) error
+
+// Watcher polls config.yaml and the SSL files it references, and reloads the config when any of them change
+type Watcher struct {
+	interval time.Duration
+	onReload ReloadHandler
+
+	lock   sync.Mutex
+	config *StaiConfig
+	files  map[string]fileState
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// fileState is what the watcher compares between polls to decide if a file changed
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// NewWatcher returns a watcher for the files used by cfg
+// The watcher does nothing until Start is called
+func NewWatcher(cfg *StaiConfig, interval time.Duration, onReload ReloadHandler) *Watcher {
+	w := &Watcher{
+		interval: interval,
+		onReload: onReload,
+		config:   cfg,
+	}
+	w.files = w.snapshot(cfg)
+
+	return w
+}
+
+// Start polls for changes in the background until Stop is called
+func (w *Watcher) Start() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.stop != nil {
+		return
+	}
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+
+	go w.run(w.stop, w.done)
+}
+
+// Stop stops polling for changes
+func (w *Watcher) Stop() {
+	w.lock.Lock()
+	stop, done := w.stop, w.done
+	w.stop, w.done = nil, nil
+	w.lock.Unlock()
+
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+func (w *Watcher) run(stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if _, err := w.Check(); err != nil {
+				log.Printf("Error reloading config: %s\n", err.Error())
+			}
+		}
+	}
+}
+
+// Check reloads the config and calls the reload handler if any of the watched files changed since the last check
+// Returns true if the config was reloaded
+func (w *Watcher) Check() (bool, error) {
+	w.lock.Lock()
+	current := w.config
+	files := w.files
+	w.lock.Unlock()
+
+	// Take the snapshot before loading, so changes made while loading or while the handler runs are picked up on
+	// the next poll
+	before := w.snapshot(current)
+	if filesEqual(files, before) {
+		return false, nil
+	}
+
+	cfg, err := LoadStaiConfig(current.StaiRoot)
+	if err != nil {
+		return false, err
+	}
+	cfg.profile = current.profile
+
+	// The reloaded config can reference different SSL files. Files that were not watched before have only just been
+	// found, so they are stored as they are now
+	snapshot := w.snapshot(cfg)
+	for path, state := range before {
+		if _, ok := snapshot[path]; ok {
+			snapshot[path] = state
+		}
+	}
+
+	err = w.onReload(cfg)
+	if err != nil {
+		return false, err
+	}
+
+	w.lock.Lock()
+	w.config = cfg
+	w.files = snapshot
+	w.lock.Unlock()
+
+	return true, nil
+}
+
+// snapshot returns the current state of config.yaml and every SSL file referenced by cfg
+func (w *Watcher) snapshot(cfg *StaiConfig) map[string]fileState {
+	files := map[string]fileState{}
+	for _, path := range cfg.watchedFiles() {
+		state := fileState{}
+		if info, err := os.Stat(path); err == nil {
+			state = fileState{exists: true, size: info.Size(), modTime: info.ModTime()}
+		}
+		files[path] = state
+	}
+
+	return files
+}
+
+func filesEqual(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, state := range a {
+		other, ok := b[path]
+		if !ok || !state.modTime.Equal(other.modTime) || state.size != other.size || state.exists != other.exists {
+			return false
+		}
+	}
+
+	return true
+}
+
+// watchedFiles returns config.yaml and the full path to every SSL file in the config
+func (c *StaiConfig) watchedFiles() []string {
+	files := []string{c.GetFullPath(filepath.Join("config", "config.yaml"))}
+	if c.configPath != "" {
+		files[0] = c.configPath
+	}
+
+	sslConfigs := []SSLConfig{
+		c.DaemonSSL,
+		c.Farmer.SSL,
+		c.FullNode.SSL,
+		c.Harvester.SSL,
+		c.Wallet.SSL,
+		c.Seeder.CrawlerConfig.SSL,
+		c.Timelord.SSL,
+		c.Introducer.SSL,
+	}
+	for _, ssl := range sslConfigs {
+		for _, file := range []string{ssl.PrivateCRT, ssl.PrivateKey, ssl.PublicCRT, ssl.PublicKey} {
+			if file != "" {
+				files = append(files, c.GetFullPath(file))
+			}
+		}
+	}
+
+	return files
+}
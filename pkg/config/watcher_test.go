@@ -0,0 +1,78 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/config"
+)
+
+func TestWatcherCheck(t *testing.T) {
+	root := writeTestConfig(t)
+	configPath := filepath.Join(root, "config", "config.yaml")
+	certPath := filepath.Join(root, "config", "ssl", "daemon", "private_daemon.crt")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(certPath), 0755))
+	assert.NoError(t, os.WriteFile(certPath, []byte("cert"), 0600))
+
+	configBytes, err := os.ReadFile(configPath)
+	assert.NoError(t, err)
+	configBytes = append(configBytes, []byte("daemon_ssl:\n  private_crt: config/ssl/daemon/private_daemon.crt\n")...)
+	assert.NoError(t, os.WriteFile(configPath, configBytes, 0600))
+
+	cfg, err := config.LoadStaiConfig(root)
+	assert.NoError(t, err)
+
+	var reloaded *config.StaiConfig
+	var reloadErr error
+	watcher := config.NewWatcher(cfg, time.Hour, func(cfg *config.StaiConfig) error {
+		reloaded = cfg
+		return reloadErr
+	})
+
+	changed, err := watcher.Check()
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	// A failed reload is retried on the next check
+	reloadErr = errors.New("half written")
+	assert.NoError(t, os.WriteFile(certPath, []byte("rotated cert"), 0600))
+	changed, err = watcher.Check()
+	assert.Error(t, err)
+	assert.False(t, changed)
+
+	reloadErr = nil
+	changed, err = watcher.Check()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.NotNil(t, reloaded)
+
+	changed, err = watcher.Check()
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	reloaded.DaemonPort = 55401
+	assert.NoError(t, reloaded.Save())
+	changed, err = watcher.Check()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, uint16(55401), reloaded.DaemonPort)
+
+	// Files referenced for the first time by the reloaded config are watched from then on
+	keyPath := filepath.Join(root, "config", "ssl", "daemon", "private_daemon.key")
+	assert.NoError(t, os.WriteFile(keyPath, []byte("key"), 0600))
+	reloaded.DaemonSSL.PrivateKey = "config/ssl/daemon/private_daemon.key"
+	assert.NoError(t, reloaded.Save())
+	changed, err = watcher.Check()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	assert.NoError(t, os.WriteFile(keyPath, []byte("rotated key"), 0600))
+	changed, err = watcher.Check()
+	assert.NoError(t, err)
+	assert.True(t, changed)
+}
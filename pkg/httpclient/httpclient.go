@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -24,6 +25,13 @@ type HTTPClient struct {
 	// If set > 0, will configure http requests with a cache
 	cacheValidTime time.Duration
 
+	// If set > 0, config and certificates are reloaded when they change
+	reloadInterval time.Duration
+	watcher        *config.Watcher
+
+	// lock guards the ports, key pairs, and clients, which are replaced when the config is reloaded
+	lock sync.RWMutex
+
 	nodePort    uint16
 	nodeKeyPair *tls.Certificate
 	nodeClient  *http.Client
@@ -49,13 +57,8 @@ type HTTPClient struct {
 func NewHTTPClient(cfg *config.StaiConfig, options ...rpcinterface.ClientOptionFunc) (*HTTPClient, error) {
 	c := &HTTPClient{
 		config: cfg,
-
-		nodePort:      cfg.FullNode.RPCPort,
-		farmerPort:    cfg.Farmer.RPCPort,
-		harvesterPort: cfg.Harvester.RPCPort,
-		walletPort:    cfg.Wallet.RPCPort,
-		crawlerPort:   cfg.Seeder.CrawlerConfig.RPCPort,
 	}
+	c.portsFromConfig()
 
 	// Sets the default host. Can be overridden by client options
 	err := c.SetBaseURL(&url.URL{
@@ -86,6 +89,8 @@ func NewHTTPClient(cfg *config.StaiConfig, options ...rpcinterface.ClientOptionF
 		return nil, err
 	}
 
+	c.restartWatcher()
+
 	return c, nil
 }
 
@@ -101,6 +106,77 @@ func (c *HTTPClient) SetCacheValidTime(validTime time.Duration) {
 	c.cacheValidTime = validTime
 }
 
+// SetConfigReloadInterval sets how often to check config.yaml and the SSL files for changes
+// When they change, the ports, key pairs, and http clients are rebuilt. An interval of 0 disables reloading
+func (c *HTTPClient) SetConfigReloadInterval(interval time.Duration) {
+	c.reloadInterval = interval
+
+	// Before the http clients exist, the watcher is started at the end of NewHTTPClient instead
+	c.lock.RLock()
+	initialized := c.nodeClient != nil
+	c.lock.RUnlock()
+	if initialized {
+		c.restartWatcher()
+	}
+}
+
+func (c *HTTPClient) restartWatcher() {
+	if c.watcher != nil {
+		c.watcher.Stop()
+		c.watcher = nil
+	}
+	if c.reloadInterval <= 0 {
+		return
+	}
+
+	c.lock.RLock()
+	cfg := c.config
+	c.lock.RUnlock()
+
+	c.watcher = config.NewWatcher(cfg, c.reloadInterval, c.reload)
+	c.watcher.Start()
+}
+
+// reload rebuilds the ports, key pairs, and http clients from cfg
+// If anything fails to load, the existing clients are kept
+func (c *HTTPClient) reload(cfg *config.StaiConfig) error {
+	next := &HTTPClient{
+		config:         cfg,
+		baseURL:        c.baseURL,
+		cacheValidTime: c.cacheValidTime,
+	}
+	next.portsFromConfig()
+
+	err := next.initialKeyPairs()
+	if err != nil {
+		return err
+	}
+
+	err = next.generateHTTPClients()
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	previous := []*http.Client{c.nodeClient, c.farmerClient, c.harvesterClient, c.walletClient, c.crawlerClient}
+
+	c.config = next.config
+
+	c.nodePort, c.nodeKeyPair, c.nodeClient = next.nodePort, next.nodeKeyPair, next.nodeClient
+	c.farmerPort, c.farmerKeyPair, c.farmerClient = next.farmerPort, next.farmerKeyPair, next.farmerClient
+	c.harvesterPort, c.harvesterKeyPair, c.harvesterClient = next.harvesterPort, next.harvesterKeyPair, next.harvesterClient
+	c.walletPort, c.walletKeyPair, c.walletClient = next.walletPort, next.walletKeyPair, next.walletClient
+	c.crawlerPort, c.crawlerKeyPair, c.crawlerClient = next.crawlerPort, next.crawlerKeyPair, next.crawlerClient
+	c.lock.Unlock()
+
+	// In flight requests finish on the old clients, but idle connections using the old certificates are closed
+	for _, client := range previous {
+		client.CloseIdleConnections()
+	}
+
+	return nil
+}
+
 // NewRequest creates an RPC request for the specified service
 func (c *HTTPClient) NewRequest(service rpcinterface.ServiceType, rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
 	// Always POST
@@ -180,35 +256,53 @@ func (c *HTTPClient) Do(req *rpcinterface.Request, v interface{}) (*http.Respons
 	return resp, err
 }
 
+// portsFromConfig sets the ports for each service based on config, falling back to the profile's default ports
+func (c *HTTPClient) portsFromConfig() {
+	defaults := c.config.Profile().DefaultPorts
+
+	c.nodePort = portOrDefault(c.config.FullNode.RPCPort, defaults.FullNodeRPC)
+	c.farmerPort = portOrDefault(c.config.Farmer.RPCPort, defaults.FarmerRPC)
+	c.harvesterPort = portOrDefault(c.config.Harvester.RPCPort, defaults.HarvesterRPC)
+	c.walletPort = portOrDefault(c.config.Wallet.RPCPort, defaults.WalletRPC)
+	c.crawlerPort = portOrDefault(c.config.Seeder.CrawlerConfig.RPCPort, defaults.CrawlerRPC)
+}
+
+func portOrDefault(port uint16, defaultPort uint16) uint16 {
+	if port != 0 {
+		return port
+	}
+	return defaultPort
+}
+
 // Sets the initial key pairs based on config
 func (c *HTTPClient) initialKeyPairs() error {
 	var err error
 
-	c.nodeKeyPair, err = c.config.FullNode.SSL.LoadPrivateKeyPair()
+	c.nodeKeyPair, err = c.config.FullNode.SSL.LoadPrivateKeyPairFromRoot(c.config.StaiRoot)
 	if err != nil {
 		return fmt.Errorf("error loading full node config: %w", err)
 	}
 
-	c.farmerKeyPair, err = c.config.Farmer.SSL.LoadPrivateKeyPair()
+	c.farmerKeyPair, err = c.config.Farmer.SSL.LoadPrivateKeyPairFromRoot(c.config.StaiRoot)
 	if err != nil {
 		return fmt.Errorf("error loading farmer config: %w", err)
 	}
 
-	c.harvesterKeyPair, err = c.config.Harvester.SSL.LoadPrivateKeyPair()
+	c.harvesterKeyPair, err = c.config.Harvester.SSL.LoadPrivateKeyPairFromRoot(c.config.StaiRoot)
 	if err != nil {
 		return fmt.Errorf("error loading harvester config: %w", err)
 	}
 
-	c.walletKeyPair, err = c.config.Wallet.SSL.LoadPrivateKeyPair()
+	c.walletKeyPair, err = c.config.Wallet.SSL.LoadPrivateKeyPairFromRoot(c.config.StaiRoot)
 	if err != nil {
 		return fmt.Errorf("error loading wallet config: %w", err)
 	}
 
-	c.crawlerKeyPair, err = c.config.Seeder.CrawlerConfig.SSL.LoadPrivateKeyPair()
+	c.crawlerKeyPair, err = c.config.Seeder.CrawlerConfig.SSL.LoadPrivateKeyPairFromRoot(c.config.StaiRoot)
 	if err != nil {
 		// Fall back to just using the full node certs in this case
 		// This should only happen on old installations that didn't have the crawler in the config initially
-		c.crawlerKeyPair, err = c.config.FullNode.SSL.LoadPrivateKeyPair()
+		c.crawlerKeyPair, err = c.config.FullNode.SSL.LoadPrivateKeyPairFromRoot(c.config.StaiRoot)
 		if err != nil {
 			return fmt.Errorf("error loading crawler config: %w", err)
 		}
@@ -299,6 +393,9 @@ func (c *HTTPClient) generateHTTPClientForService(service rpcinterface.ServiceTy
 
 // portForService returns the configured port for the service
 func (c *HTTPClient) portForService(service rpcinterface.ServiceType) uint16 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
 	var port uint16 = 0
 
 	switch service {
@@ -319,6 +416,9 @@ func (c *HTTPClient) portForService(service rpcinterface.ServiceType) uint16 {
 
 // httpClientForService returns the proper http client to use with the service
 func (c *HTTPClient) httpClientForService(service rpcinterface.ServiceType) (*http.Client, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
 	var client *http.Client
 
 	switch service {
@@ -0,0 +1,112 @@
+package profile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Profile describes the names and defaults used by a Chia derived blockchain, so the same library can
+// be used with installations of different chains side by side
+type Profile struct {
+	// Name is a human readable name for the chain
+	Name string
+
+	// RootEnv is the environment variable that overrides the root path of the installation, such as STAI_ROOT
+	RootEnv string
+
+	// DefaultRoot is the root path of the installation relative to the user's home directory, such as .stai/mainnet
+	DefaultRoot string
+
+	// ServicePrefix is prepended to service names in websocket messages, such as stai in stai_full_node
+	ServicePrefix string
+
+	// AddressPrefix is the bech32m prefix for mainnet addresses
+	AddressPrefix string
+
+	// Origin identifies this library in websocket requests sent to the daemon
+	Origin string
+
+	// DefaultPorts are used when a port is missing from config.yaml
+	DefaultPorts Ports
+}
+
+// Ports are the default ports for each service
+// A value of 0 means there is no default, and the port must be set in config.yaml
+type Ports struct {
+	Daemon       uint16
+	FullNode     uint16
+	FullNodeRPC  uint16
+	Farmer       uint16
+	FarmerRPC    uint16
+	Harvester    uint16
+	HarvesterRPC uint16
+	Wallet       uint16
+	WalletRPC    uint16
+	CrawlerRPC   uint16
+}
+
+// STAI is the profile for STAI installations
+// STAI always writes the ports to config.yaml, so no default ports are assumed
+var STAI = &Profile{
+	Name:          "STAI",
+	RootEnv:       "STAI_ROOT",
+	DefaultRoot:   filepath.Join(".stai", "mainnet"),
+	ServicePrefix: "stai",
+	AddressPrefix: "stai",
+	Origin:        "go-stai-rpc",
+}
+
+// Chia is the profile for Chia installations
+var Chia = &Profile{
+	Name:          "Chia",
+	RootEnv:       "CHIA_ROOT",
+	DefaultRoot:   filepath.Join(".chia", "mainnet"),
+	ServicePrefix: "chia",
+	AddressPrefix: "xch",
+	Origin:        "go-stai-rpc",
+	DefaultPorts: Ports{
+		Daemon:       55400,
+		FullNode:     8444,
+		FullNodeRPC:  8555,
+		Farmer:       8447,
+		FarmerRPC:    8559,
+		Harvester:    8448,
+		HarvesterRPC: 8560,
+		Wallet:       8449,
+		WalletRPC:    9256,
+		CrawlerRPC:   8561,
+	},
+}
+
+// Default is the profile used when no profile is specified
+var Default = STAI
+
+// RootPath returns the root path of the installation
+// The RootEnv environment variable is used if set, otherwise DefaultRoot within the user's home directory
+func (p *Profile) RootPath() (string, error) {
+	if p.RootEnv != "" {
+		if root, ok := os.LookupEnv(p.RootEnv); ok {
+			return root, nil
+		}
+	}
+
+	if p.DefaultRoot == "" {
+		return "", fmt.Errorf("profile %s has no default root path", p.Name)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, p.DefaultRoot), nil
+}
+
+// ServiceName returns the name of a service as used in websocket messages, such as stai_full_node for full_node
+func (p *Profile) ServiceName(service string) string {
+	if p.ServicePrefix == "" {
+		return service
+	}
+	return fmt.Sprintf("%s_%s", p.ServicePrefix, service)
+}
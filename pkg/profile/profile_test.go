@@ -0,0 +1,31 @@
+package profile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/profile"
+)
+
+func TestRootPath(t *testing.T) {
+	t.Setenv("CHIA_ROOT", "/srv/chia")
+	root, err := profile.Chia.RootPath()
+	assert.NoError(t, err)
+	assert.Equal(t, "/srv/chia", root)
+
+	home, err := os.UserHomeDir()
+	assert.NoError(t, err)
+	p := &profile.Profile{Name: "Test", RootEnv: "GO_STAI_LIBS_UNSET_ROOT", DefaultRoot: filepath.Join(".test", "mainnet")}
+	root, err = p.RootPath()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".test", "mainnet"), root)
+}
+
+func TestServiceName(t *testing.T) {
+	assert.Equal(t, "stai_full_node", profile.STAI.ServiceName("full_node"))
+	assert.Equal(t, "chia_wallet", profile.Chia.ServiceName("wallet"))
+	assert.Equal(t, "farmer", (&profile.Profile{}).ServiceName("farmer"))
+}
@@ -0,0 +1,314 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// DefaultBlockIteratorBatchSize is the number of blocks fetched per request unless configured otherwise
+const DefaultBlockIteratorBatchSize = 50
+
+// maxBatchFetchAttempts is how many times a batch is fetched when the records and blocks keep coming from different forks
+const maxBatchFetchAttempts = 3
+
+// BlockIteratorOptions configures a block iterator
+type BlockIteratorOptions struct {
+	// Start is the first height to return. To resume iterating, set this to one more than the last height processed
+	Start uint32
+
+	// End is the height to stop at (exclusive). If 0, iterates up to and including the peak at the time the iterator is created
+	End uint32
+
+	// BatchSize is the number of blocks fetched per request. Defaults to DefaultBlockIteratorBatchSize
+	BatchSize uint32
+
+	// Concurrency is the number of batches fetched in parallel. Defaults to 1
+	Concurrency int
+
+	// SkipBlocks only fetches block records. Block will always return nil
+	SkipBlocks bool
+
+	// SkipRecords only fetches full blocks. Record will always return nil
+	SkipRecords bool
+}
+
+// BlockIterator iterates over a range of blocks in height order
+// Batches are fetched in parallel, but are always delivered in order
+// This requires the HTTP connection mode, since websocket responses are not returned from requests
+type BlockIterator struct {
+	service *FullNodeService
+	opts    BlockIteratorOptions
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	batches chan chan *blockBatch
+	current *blockBatch
+	index   int
+
+	done      bool
+	err       error
+	closeOnce sync.Once
+}
+
+// blockBatch is the result of fetching a single batch of blocks
+type blockBatch struct {
+	start        uint32
+	blocks       []*types.FullBlock
+	headerHashes []types.Bytes32
+	records      []*types.BlockRecord
+	err          error
+}
+
+// len returns the number of heights in the batch
+func (b *blockBatch) len() int {
+	if b.records != nil {
+		return len(b.records)
+	}
+	return len(b.blocks)
+}
+
+// NewBlockIterator returns an iterator over the blocks in the range specified by opts
+// The iterator must be closed when it is no longer needed if it was not iterated to the end
+func (s *FullNodeService) NewBlockIterator(ctx context.Context, opts BlockIteratorOptions) (*BlockIterator, error) {
+	if opts.SkipBlocks && opts.SkipRecords {
+		return nil, errors.New("block iterator must fetch blocks, records, or both")
+	}
+	if opts.BatchSize == 0 {
+		opts.BatchSize = DefaultBlockIteratorBatchSize
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	if opts.End == 0 {
+		state, _, err := s.GetBlockchainState()
+		if err != nil {
+			return nil, err
+		}
+		if state.BlockchainState == nil || state.BlockchainState.Peak == nil {
+			return nil, errors.New("unable to determine peak height")
+		}
+		opts.End = state.BlockchainState.Peak.Height + 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	it := &BlockIterator{
+		service: s,
+		opts:    opts,
+		ctx:     ctx,
+		cancel:  cancel,
+		// Limits how many batches can be fetched ahead of the consumer
+		batches: make(chan chan *blockBatch, opts.Concurrency),
+	}
+
+	go it.schedule()
+
+	return it, nil
+}
+
+// schedule queues up each batch in order, and starts a fetch for each
+// The queue is bounded by Concurrency, so at most Concurrency fetches run ahead of the consumer
+func (it *BlockIterator) schedule() {
+	defer close(it.batches)
+
+	for start := it.opts.Start; start < it.opts.End; {
+		end := start + it.opts.BatchSize
+		if end > it.opts.End || end < start {
+			end = it.opts.End
+		}
+
+		result := make(chan *blockBatch, 1)
+		select {
+		case it.batches <- result:
+		case <-it.ctx.Done():
+			return
+		}
+
+		go func(start, end uint32) {
+			result <- it.fetch(start, end)
+		}(start, end)
+
+		start = end
+	}
+}
+
+// fetch gets the blocks and/or records for a single batch
+// Records and blocks are separate requests, so a reorg in between can return them from different forks. When the
+// blocks don't link up with the records, the whole batch is fetched again
+func (it *BlockIterator) fetch(start, end uint32) *blockBatch {
+	for attempt := 1; ; attempt++ {
+		batch := it.fetchOnce(start, end)
+		if batch.err != nil || batch.records == nil || batch.blocks == nil {
+			return batch
+		}
+
+		if batch.sameChain() {
+			return batch
+		}
+		if attempt == maxBatchFetchAttempts || it.ctx.Err() != nil {
+			batch.err = fmt.Errorf("block records and blocks starting at height %d are from different forks after %d attempts", start, attempt)
+			return batch
+		}
+	}
+}
+
+// fetchOnce requests the blocks and/or records for a single batch
+func (it *BlockIterator) fetchOnce(start, end uint32) *blockBatch {
+	batch := &blockBatch{start: start}
+	expected := int(end - start)
+
+	if !it.opts.SkipRecords {
+		records, _, err := it.service.GetBlockRecords(&GetBlockRecordsOptions{
+			Start: int(start),
+			End:   int(end),
+		})
+		if err != nil {
+			batch.err = err
+			return batch
+		}
+		if len(records.BlockRecords) != expected {
+			batch.err = fmt.Errorf("expected %d block records starting at height %d, got %d", expected, start, len(records.BlockRecords))
+			return batch
+		}
+		for i, record := range records.BlockRecords {
+			if record == nil || record.Height != start+uint32(i) {
+				batch.err = fmt.Errorf("block record at height %d missing or out of order", start+uint32(i))
+				return batch
+			}
+		}
+		batch.records = records.BlockRecords
+	}
+
+	if !it.opts.SkipBlocks {
+		blocks, _, err := it.service.GetBlocks(&GetBlocksOptions{
+			Start:          int(start),
+			End:            int(end),
+			ExcludeReorged: true,
+		})
+		if err != nil {
+			batch.err = err
+			return batch
+		}
+		if len(blocks.Blocks) != expected {
+			batch.err = fmt.Errorf("expected %d blocks starting at height %d, got %d", expected, start, len(blocks.Blocks))
+			return batch
+		}
+		for i, block := range blocks.Blocks {
+			if block == nil || block.RewardChainBlock == nil || block.Foliage == nil || block.RewardChainBlock.Height != start+uint32(i) {
+				batch.err = fmt.Errorf("block at height %d missing or out of order", start+uint32(i))
+				return batch
+			}
+		}
+		batch.blocks = blocks.Blocks
+		batch.headerHashes = blocks.HeaderHashes
+	}
+
+	return batch
+}
+
+// sameChain checks the blocks and records are from the same chain, using the hashes the node returned
+// Every block must have the header hash and previous hash of the record at the same height, and each record must
+// follow on from the one before it
+func (b *blockBatch) sameChain() bool {
+	for i, block := range b.blocks {
+		record := b.records[i]
+		if block.Foliage.PrevBlockHash != record.PrevHash {
+			return false
+		}
+		if b.headerHashes != nil && b.headerHashes[i] != record.HeaderHash {
+			return false
+		}
+		if i > 0 && record.PrevHash != b.records[i-1].HeaderHash {
+			return false
+		}
+	}
+	return true
+}
+
+// Next advances to the next block, returning false when there are no more blocks or an error occurred
+// Check Err after Next returns false
+func (it *BlockIterator) Next() bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	if it.current != nil && it.index+1 < it.current.len() {
+		it.index++
+		return true
+	}
+
+	for {
+		var result chan *blockBatch
+		var ok bool
+		select {
+		case result, ok = <-it.batches:
+		case <-it.ctx.Done():
+			it.err = it.ctx.Err()
+			return false
+		}
+		if !ok {
+			it.current = nil
+			it.done = true
+			it.Close()
+			return false
+		}
+
+		var batch *blockBatch
+		select {
+		case batch = <-result:
+		case <-it.ctx.Done():
+			it.err = it.ctx.Err()
+			return false
+		}
+		if batch.err != nil {
+			it.err = batch.err
+			it.Close()
+			return false
+		}
+		if batch.len() == 0 {
+			continue
+		}
+
+		it.current = batch
+		it.index = 0
+		return true
+	}
+}
+
+// Height returns the height of the current block
+func (it *BlockIterator) Height() uint32 {
+	if it.current == nil {
+		return 0
+	}
+	return it.current.start + uint32(it.index)
+}
+
+// Block returns the current full block, or nil if SkipBlocks is set
+func (it *BlockIterator) Block() *types.FullBlock {
+	if it.current == nil || it.current.blocks == nil {
+		return nil
+	}
+	return it.current.blocks[it.index]
+}
+
+// Record returns the current block record, or nil if SkipRecords is set
+func (it *BlockIterator) Record() *types.BlockRecord {
+	if it.current == nil || it.current.records == nil {
+		return nil
+	}
+	return it.current.records[it.index]
+}
+
+// Err returns the error that stopped iteration, if any
+func (it *BlockIterator) Err() error {
+	return it.err
+}
+
+// Close stops any pending fetches
+func (it *BlockIterator) Close() {
+	it.closeOnce.Do(it.cancel)
+}
@@ -0,0 +1,201 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/rpcinterface"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// fakeChainClient serves block records and blocks for heights below peak
+type fakeChainClient struct {
+	peak uint32
+	// forkedBlocks is the number of get_blocks responses served from a different fork than the block records
+	forkedBlocks int
+
+	lock     sync.Mutex
+	requests []rpcinterface.Endpoint
+}
+
+// fakeHash returns the header hash of the block at the height on the fork
+func fakeHash(height uint32, fork byte) types.Bytes32 {
+	return types.Bytes32{fork + 1, byte(height >> 8), byte(height)}
+}
+
+// fakeBlock returns a block at the height on the fork
+func fakeBlock(height uint32, fork byte) *types.FullBlock {
+	return &types.FullBlock{
+		RewardChainBlock: &types.RewardChainBlock{Height: height},
+		Foliage: &types.Foliage{
+			PrevBlockHash: fakeHash(height-1, fork),
+		},
+	}
+}
+
+// fakeBlockJSON is a block as returned by get_blocks, which adds the header hash
+type fakeBlockJSON struct {
+	*types.FullBlock
+	HeaderHash types.Bytes32 `json:"header_hash"`
+}
+
+func (f *fakeChainClient) NewRequest(service rpcinterface.ServiceType, rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
+	return &rpcinterface.Request{Service: service, Endpoint: rpcEndpoint, Data: opt}, nil
+}
+
+func (f *fakeChainClient) Do(req *rpcinterface.Request, v interface{}) (*http.Response, error) {
+	f.lock.Lock()
+	f.requests = append(f.requests, req.Endpoint)
+	f.lock.Unlock()
+
+	var resp interface{}
+	switch req.Endpoint {
+	case "get_blockchain_state":
+		resp = &GetBlockchainStateResponse{Success: true, BlockchainState: &types.BlockchainState{Peak: &types.BlockRecord{Height: f.peak}}}
+	case "get_block_records":
+		opts := req.Data.(*GetBlockRecordsOptions)
+		// Finish later batches first, to make sure the iterator still delivers in order
+		time.Sleep(time.Duration(1000-opts.Start) * time.Microsecond)
+		records := &GetBlockRecordsResponse{Success: true}
+		for height := opts.Start; height < opts.End && height <= int(f.peak); height++ {
+			records.BlockRecords = append(records.BlockRecords, &types.BlockRecord{
+				Height:     uint32(height),
+				HeaderHash: fakeHash(uint32(height), 0),
+				PrevHash:   fakeHash(uint32(height)-1, 0),
+			})
+		}
+		resp = records
+	case "get_block_record_by_height":
+		// Answers like a node asked for a height it does not have
+		resp = &GetBlockRecordResponse{Success: false}
+	case "get_blocks":
+		opts := req.Data.(*GetBlocksOptions)
+		blocks := struct {
+			Success bool            `json:"success"`
+			Blocks  []fakeBlockJSON `json:"blocks"`
+		}{Success: true}
+		fork := byte(0)
+		f.lock.Lock()
+		if f.forkedBlocks > 0 {
+			f.forkedBlocks--
+			fork = 1
+		}
+		f.lock.Unlock()
+		for height := opts.Start; height < opts.End && height <= int(f.peak); height++ {
+			blocks.Blocks = append(blocks.Blocks, fakeBlockJSON{fakeBlock(uint32(height), fork), fakeHash(uint32(height), fork)})
+		}
+		resp = blocks
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return nil, json.Unmarshal(data, v)
+}
+
+func (f *fakeChainClient) SetBaseURL(url *url.URL) error                                  { return nil }
+func (f *fakeChainClient) SetCacheValidTime(validTime time.Duration)                      {}
+func (f *fakeChainClient) SubscribeSelf() error                                           { return nil }
+func (f *fakeChainClient) Subscribe(service string) error                                 { return nil }
+func (f *fakeChainClient) ListenSync(handler rpcinterface.WebsocketResponseHandler) error { return nil }
+func (f *fakeChainClient) AddDisconnectHandler(onDisconnect rpcinterface.DisconnectHandler) {
+}
+func (f *fakeChainClient) AddReconnectHandler(onReconnect rpcinterface.ReconnectHandler) {}
+
+func newFakeFullNodeService(peak uint32) *FullNodeService {
+	return &FullNodeService{client: &Client{activeClient: &fakeChainClient{peak: peak}}}
+}
+
+// TestBlockIteratorOrdered Ensures blocks fetched in parallel are delivered in height order up to the peak
+func TestBlockIteratorOrdered(t *testing.T) {
+	service := newFakeFullNodeService(104)
+	it, err := service.NewBlockIterator(context.Background(), BlockIteratorOptions{
+		Start:       10,
+		BatchSize:   7,
+		Concurrency: 4,
+	})
+	assert.NoError(t, err)
+
+	expected := uint32(10)
+	for it.Next() {
+		assert.Equal(t, expected, it.Height())
+		assert.Equal(t, expected, it.Record().Height)
+		assert.Equal(t, expected, it.Block().RewardChainBlock.Height)
+		expected++
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, uint32(105), expected)
+}
+
+// TestBlockIteratorRecordsOnly Ensures full blocks are not fetched when skipped
+func TestBlockIteratorRecordsOnly(t *testing.T) {
+	service := newFakeFullNodeService(1000)
+	it, err := service.NewBlockIterator(context.Background(), BlockIteratorOptions{
+		Start:      0,
+		End:        20,
+		SkipBlocks: true,
+	})
+	assert.NoError(t, err)
+
+	count := 0
+	for it.Next() {
+		assert.Nil(t, it.Block())
+		assert.NotNil(t, it.Record())
+		count++
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 20, count)
+
+	fake := service.client.activeClient.(*fakeChainClient)
+	assert.NotContains(t, fake.requests, rpcinterface.Endpoint("get_blocks"))
+}
+
+// TestBlockIteratorBeyondPeak Ensures an error is returned when the node does not have the requested blocks
+func TestBlockIteratorBeyondPeak(t *testing.T) {
+	service := newFakeFullNodeService(15)
+	it, err := service.NewBlockIterator(context.Background(), BlockIteratorOptions{
+		Start:     10,
+		End:       30,
+		BatchSize: 10,
+	})
+	assert.NoError(t, err)
+
+	count := 0
+	for it.Next() {
+		count++
+	}
+	assert.Error(t, it.Err())
+	assert.Equal(t, 0, count)
+}
+
+// TestBlockIteratorRefetchesForks Ensures a batch is fetched again when the blocks and records are from different forks
+func TestBlockIteratorRefetchesForks(t *testing.T) {
+	service := newFakeFullNodeService(100)
+	fake := service.client.activeClient.(*fakeChainClient)
+	fake.forkedBlocks = 2
+
+	it, err := service.NewBlockIterator(context.Background(), BlockIteratorOptions{Start: 0, End: 10})
+	assert.NoError(t, err)
+	count := 0
+	for it.Next() {
+		assert.Equal(t, it.Record().PrevHash, it.Block().Foliage.PrevBlockHash)
+		count++
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 10, count)
+
+	// Gives up when the forks keep differing
+	fake.forkedBlocks = maxBatchFetchAttempts
+	it, err = service.NewBlockIterator(context.Background(), BlockIteratorOptions{Start: 0, End: 10})
+	assert.NoError(t, err)
+	assert.False(t, it.Next())
+	assert.Error(t, it.Err())
+}
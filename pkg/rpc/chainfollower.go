@@ -0,0 +1,401 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// ChainEventType is the type of change to the chain
+type ChainEventType uint8
+
+const (
+	// BlockConnected the block was added to the end of the followed chain
+	BlockConnected ChainEventType = iota
+
+	// BlockDisconnected the block was removed from the end of the followed chain because of a reorg
+	BlockDisconnected
+)
+
+// String returns a readable name for the event type
+func (t ChainEventType) String() string {
+	switch t {
+	case BlockConnected:
+		return "BlockConnected"
+	case BlockDisconnected:
+		return "BlockDisconnected"
+	}
+	return "Unknown"
+}
+
+// ChainEvent is a single block being connected to or disconnected from the followed chain
+type ChainEvent struct {
+	Type   ChainEventType
+	Record *types.BlockRecord
+
+	// Block is only set for connected blocks, when FetchFullBlocks is enabled
+	Block *types.FullBlock
+}
+
+// ChainEventHandler processes a chain event
+// If an error is returned, the follower stops and the checkpoint is not advanced past the event
+type ChainEventHandler func(event *ChainEvent) error
+
+// Checkpoint is the last block the chain follower processed
+type Checkpoint struct {
+	Height     uint32        `json:"height"`
+	HeaderHash types.Bytes32 `json:"header_hash"`
+}
+
+// CheckpointStore persists the chain follower's checkpoint so it can resume after restarts
+type CheckpointStore interface {
+	// LoadCheckpoint returns the saved checkpoint, or nil if there is none
+	LoadCheckpoint() (*Checkpoint, error)
+
+	// SaveCheckpoint saves the checkpoint. A nil checkpoint means no blocks have been processed
+	SaveCheckpoint(checkpoint *Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory only
+type MemoryCheckpointStore struct {
+	lock       sync.Mutex
+	checkpoint *Checkpoint
+}
+
+// LoadCheckpoint returns the checkpoint
+func (s *MemoryCheckpointStore) LoadCheckpoint() (*Checkpoint, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.checkpoint == nil {
+		return nil, nil
+	}
+	checkpoint := *s.checkpoint
+	return &checkpoint, nil
+}
+
+// SaveCheckpoint stores the checkpoint
+func (s *MemoryCheckpointStore) SaveCheckpoint(checkpoint *Checkpoint) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if checkpoint == nil {
+		s.checkpoint = nil
+		return nil
+	}
+	saved := *checkpoint
+	s.checkpoint = &saved
+	return nil
+}
+
+// FileCheckpointStore stores the checkpoint as json in a file
+type FileCheckpointStore struct {
+	Path string
+}
+
+// LoadCheckpoint reads the checkpoint from the file
+func (s *FileCheckpointStore) LoadCheckpoint() (*Checkpoint, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var checkpoint *Checkpoint
+	err = json.Unmarshal(data, &checkpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+// SaveCheckpoint writes the checkpoint to a temporary file, then moves it into place
+func (s *FileCheckpointStore) SaveCheckpoint(checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	_, err = tmp.Write(data)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// ChainFollowerOptions configures a chain follower
+type ChainFollowerOptions struct {
+	// StartHeight is the first height to process when there is no checkpoint
+	StartHeight uint32
+
+	// BatchSize is the number of block records fetched per request while catching up. Defaults to DefaultBlockIteratorBatchSize
+	BatchSize uint32
+
+	// PollInterval is how often the peak is checked when no block events are received. Defaults to 10 seconds
+	PollInterval time.Duration
+
+	// FetchFullBlocks fetches the full block for every connected block
+	FetchFullBlocks bool
+}
+
+// ChainFollower follows the peak of the chain, emitting ordered events as blocks are connected and disconnected
+// This requires the HTTP connection mode. To react to new blocks immediately instead of polling, pass a websocket
+// client to WatchBlocks
+type ChainFollower struct {
+	service *FullNodeService
+	store   CheckpointStore
+	opts    ChainFollowerOptions
+
+	notify chan struct{}
+
+	tip *Checkpoint
+}
+
+// NewChainFollower returns a chain follower that resumes from the checkpoint in store
+func NewChainFollower(service *FullNodeService, store CheckpointStore, opts ChainFollowerOptions) *ChainFollower {
+	if opts.BatchSize == 0 {
+		opts.BatchSize = DefaultBlockIteratorBatchSize
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 10 * time.Second
+	}
+
+	return &ChainFollower{
+		service: service,
+		store:   store,
+		opts:    opts,
+		notify:  make(chan struct{}, 1),
+	}
+}
+
+// WatchBlocks checks for new blocks whenever the websocket client receives a block event from the full node
+func (f *ChainFollower) WatchBlocks(websocketClient *Client) error {
+	return websocketClient.OnBlock(func(event *types.BlockEvent) {
+		f.Notify()
+	})
+}
+
+// Notify wakes the follower up to check for new blocks
+func (f *ChainFollower) Notify() {
+	select {
+	case f.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run follows the chain until ctx is done or an error occurs, calling handler for every event in order
+// The checkpoint is saved after each event is handled successfully
+func (f *ChainFollower) Run(ctx context.Context, handler ChainEventHandler) error {
+	var err error
+	f.tip, err = f.store.LoadCheckpoint()
+	if err != nil {
+		return fmt.Errorf("error loading checkpoint: %w", err)
+	}
+
+	for {
+		err = f.sync(ctx, handler)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-f.notify:
+		case <-time.After(f.opts.PollInterval):
+		}
+	}
+}
+
+// Tip returns the last block processed, or nil if none have been processed yet
+// Only safe to call from the event handler, or when Run is not running
+func (f *ChainFollower) Tip() *Checkpoint {
+	return f.tip
+}
+
+// sync processes events until the followed chain matches the current peak
+func (f *ChainFollower) sync(ctx context.Context, handler ChainEventHandler) error {
+	state, _, err := f.service.GetBlockchainState()
+	if err != nil {
+		return err
+	}
+	if state.BlockchainState == nil || state.BlockchainState.Peak == nil {
+		// Node doesn't have a peak yet
+		return nil
+	}
+	peak := state.BlockchainState.Peak.Height
+
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		next := f.opts.StartHeight
+		if f.tip != nil {
+			next = f.tip.Height + 1
+		}
+
+		if next > peak {
+			// Nothing new to connect, but the chain may have reorged to a shorter, heavier chain
+			inMainChain, err := f.tipInMainChain()
+			if err != nil {
+				return err
+			}
+			if inMainChain {
+				return nil
+			}
+			err = f.disconnectTip(handler)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		end := next + f.opts.BatchSize
+		if end > peak+1 {
+			end = peak + 1
+		}
+		records, _, err := f.service.GetBlockRecords(&GetBlockRecordsOptions{
+			Start: int(next),
+			End:   int(end),
+		})
+		if err != nil {
+			return err
+		}
+		if len(records.BlockRecords) == 0 {
+			// The peak moved backwards while we were fetching; check again
+			return nil
+		}
+
+		progressed := false
+		for i, record := range records.BlockRecords {
+			if record == nil {
+				break
+			}
+			if f.tip != nil && record.PrevHash != f.tip.HeaderHash {
+				if i == 0 && record.Height == f.tip.Height+1 {
+					// The first block doesn't build on our tip, so our tip was reorged out of the chain
+					err = f.disconnectTip(handler)
+					if err != nil {
+						return err
+					}
+					progressed = true
+				}
+				// Otherwise the chain changed while we were fetching; fetch again from the current tip
+				break
+			}
+
+			err = f.connect(record, handler)
+			if err != nil {
+				return err
+			}
+			progressed = true
+		}
+
+		if !progressed {
+			// Node returned something unexpected; try again on the next check instead of spinning
+			return nil
+		}
+	}
+}
+
+// tipInMainChain checks if the tip is still the block at its height in the main chain
+func (f *ChainFollower) tipInMainChain() (bool, error) {
+	if f.tip == nil {
+		return true, nil
+	}
+
+	record, _, err := f.service.GetBlockRecordByHeight(&GetBlockByHeightOptions{BlockHeight: int(f.tip.Height)})
+	if err != nil {
+		return false, err
+	}
+	if record == nil || record.BlockRecord == nil {
+		return false, nil
+	}
+
+	return record.BlockRecord.HeaderHash == f.tip.HeaderHash, nil
+}
+
+// connect emits a BlockConnected event and advances the checkpoint to the block
+func (f *ChainFollower) connect(record *types.BlockRecord, handler ChainEventHandler) error {
+	event := &ChainEvent{Type: BlockConnected, Record: record}
+
+	if f.opts.FetchFullBlocks {
+		block, _, err := f.service.GetBlock(&GetBlockOptions{HeaderHash: record.HeaderHash.String()})
+		if err != nil {
+			return err
+		}
+		if block.Block == nil {
+			return fmt.Errorf("full block %s not found", record.HeaderHash)
+		}
+		event.Block = block.Block
+	}
+
+	err := handler(event)
+	if err != nil {
+		return err
+	}
+
+	return f.setTip(&Checkpoint{Height: record.Height, HeaderHash: record.HeaderHash})
+}
+
+// disconnectTip emits a BlockDisconnected event for the tip and moves the checkpoint back to its parent
+func (f *ChainFollower) disconnectTip(handler ChainEventHandler) error {
+	if f.tip == nil {
+		return errors.New("unable to disconnect block without a checkpoint")
+	}
+
+	record, _, err := f.service.GetBlockRecord(&GetBlockRecordOptions{HeaderHash: f.tip.HeaderHash.String()})
+	if err != nil {
+		return err
+	}
+	if record.BlockRecord == nil {
+		return fmt.Errorf("block record %s not found", f.tip.HeaderHash)
+	}
+
+	err = handler(&ChainEvent{Type: BlockDisconnected, Record: record.BlockRecord})
+	if err != nil {
+		return err
+	}
+
+	if record.BlockRecord.Height == 0 || record.BlockRecord.Height <= f.opts.StartHeight {
+		return f.setTip(nil)
+	}
+
+	return f.setTip(&Checkpoint{Height: record.BlockRecord.Height - 1, HeaderHash: record.BlockRecord.PrevHash})
+}
+
+// setTip updates the tip and saves it as the checkpoint
+func (f *ChainFollower) setTip(tip *Checkpoint) error {
+	err := f.store.SaveCheckpoint(tip)
+	if err != nil {
+		return fmt.Errorf("error saving checkpoint: %w", err)
+	}
+
+	f.tip = tip
+	return nil
+}
@@ -0,0 +1,199 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/rpcinterface"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// fakeReorgClient serves a main chain of block records, and remembers every record ever served by hash
+type fakeReorgClient struct {
+	main   []*types.BlockRecord
+	byHash map[types.Bytes32]*types.BlockRecord
+}
+
+func newFakeReorgClient() *fakeReorgClient {
+	return &fakeReorgClient{byHash: map[types.Bytes32]*types.BlockRecord{}}
+}
+
+// testHash makes a readable hash for a test block
+func testHash(label string) types.Bytes32 {
+	var hash types.Bytes32
+	copy(hash[:], label)
+	return hash
+}
+
+// testHashLabel returns the label the hash was made from
+func testHashLabel(hash types.Bytes32) string {
+	return strings.TrimRight(string(hash[:]), "\x00")
+}
+
+// extend adds blocks to the main chain, starting from height, tagging hashes with fork so forks get unique hashes
+func (f *fakeReorgClient) extend(height uint32, count int, fork string) {
+	f.main = f.main[:height]
+	for i := 0; i < count; i++ {
+		h := height + uint32(i)
+		prev := testHash("genesis")
+		if h > 0 {
+			prev = f.main[h-1].HeaderHash
+		}
+		record := &types.BlockRecord{Height: h, HeaderHash: testHash(fmt.Sprintf("%s-%d", fork, h)), PrevHash: prev}
+		f.main = append(f.main, record)
+		f.byHash[record.HeaderHash] = record
+	}
+}
+
+func (f *fakeReorgClient) NewRequest(service rpcinterface.ServiceType, rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
+	return &rpcinterface.Request{Service: service, Endpoint: rpcEndpoint, Data: opt}, nil
+}
+
+func (f *fakeReorgClient) Do(req *rpcinterface.Request, v interface{}) (*http.Response, error) {
+	var resp interface{}
+	switch req.Endpoint {
+	case "get_blockchain_state":
+		resp = &GetBlockchainStateResponse{Success: true, BlockchainState: &types.BlockchainState{Peak: f.main[len(f.main)-1]}}
+	case "get_block_records":
+		opts := req.Data.(*GetBlockRecordsOptions)
+		records := &GetBlockRecordsResponse{Success: true}
+		for height := opts.Start; height < opts.End && height < len(f.main); height++ {
+			records.BlockRecords = append(records.BlockRecords, f.main[height])
+		}
+		resp = records
+	case "get_block_record":
+		opts := req.Data.(*GetBlockRecordOptions)
+		hash, err := types.Bytes32FromHexString(opts.HeaderHash)
+		if err != nil {
+			return nil, err
+		}
+		resp = &GetBlockRecordResponse{Success: true, BlockRecord: f.byHash[hash]}
+	case "get_block_record_by_height":
+		opts := req.Data.(*GetBlockByHeightOptions)
+		record := &GetBlockRecordResponse{Success: true}
+		if opts.BlockHeight < len(f.main) {
+			record.BlockRecord = f.main[opts.BlockHeight]
+		}
+		resp = record
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return nil, json.Unmarshal(data, v)
+}
+
+func (f *fakeReorgClient) SetBaseURL(url *url.URL) error                                  { return nil }
+func (f *fakeReorgClient) SetCacheValidTime(validTime time.Duration)                      {}
+func (f *fakeReorgClient) SubscribeSelf() error                                           { return nil }
+func (f *fakeReorgClient) Subscribe(service string) error                                 { return nil }
+func (f *fakeReorgClient) ListenSync(handler rpcinterface.WebsocketResponseHandler) error { return nil }
+func (f *fakeReorgClient) AddDisconnectHandler(onDisconnect rpcinterface.DisconnectHandler) {
+}
+func (f *fakeReorgClient) AddReconnectHandler(onReconnect rpcinterface.ReconnectHandler) {}
+
+func collectEvents(t *testing.T, follower *ChainFollower) []string {
+	var events []string
+	err := follower.sync(context.Background(), func(event *ChainEvent) error {
+		events = append(events, fmt.Sprintf("%s %s", event.Type, testHashLabel(event.Record.HeaderHash)))
+		return nil
+	})
+	assert.NoError(t, err)
+	return events
+}
+
+// TestChainFollowerReorg Ensures reorged blocks are disconnected in reverse order before the new chain is connected
+func TestChainFollowerReorg(t *testing.T) {
+	chain := newFakeReorgClient()
+	chain.extend(0, 5, "a")
+
+	service := &FullNodeService{client: &Client{activeClient: chain}}
+	store := &MemoryCheckpointStore{}
+	follower := NewChainFollower(service, store, ChainFollowerOptions{BatchSize: 2})
+	follower.tip, _ = store.LoadCheckpoint()
+
+	assert.Equal(t, []string{
+		"BlockConnected a-0",
+		"BlockConnected a-1",
+		"BlockConnected a-2",
+		"BlockConnected a-3",
+		"BlockConnected a-4",
+	}, collectEvents(t, follower))
+
+	chain.extend(3, 3, "b")
+	assert.Equal(t, []string{
+		"BlockDisconnected a-4",
+		"BlockDisconnected a-3",
+		"BlockConnected b-3",
+		"BlockConnected b-4",
+		"BlockConnected b-5",
+	}, collectEvents(t, follower))
+
+	checkpoint, err := store.LoadCheckpoint()
+	assert.NoError(t, err)
+	assert.Equal(t, &Checkpoint{Height: 5, HeaderHash: testHash("b-5")}, checkpoint)
+}
+
+// TestChainFollowerShorterReorg Ensures a reorg to a shorter chain disconnects blocks above the new peak
+func TestChainFollowerShorterReorg(t *testing.T) {
+	chain := newFakeReorgClient()
+	chain.extend(0, 5, "a")
+
+	service := &FullNodeService{client: &Client{activeClient: chain}}
+	follower := NewChainFollower(service, &MemoryCheckpointStore{}, ChainFollowerOptions{})
+	collectEvents(t, follower)
+
+	chain.extend(2, 1, "b")
+	assert.Equal(t, []string{
+		"BlockDisconnected a-4",
+		"BlockDisconnected a-3",
+		"BlockDisconnected a-2",
+		"BlockConnected b-2",
+	}, collectEvents(t, follower))
+}
+
+// TestChainFollowerHandlerError Ensures the checkpoint does not advance past an event that failed to be handled
+func TestChainFollowerHandlerError(t *testing.T) {
+	chain := newFakeReorgClient()
+	chain.extend(0, 5, "a")
+
+	service := &FullNodeService{client: &Client{activeClient: chain}}
+	store := &MemoryCheckpointStore{}
+	follower := NewChainFollower(service, store, ChainFollowerOptions{})
+
+	err := follower.sync(context.Background(), func(event *ChainEvent) error {
+		if event.Record.Height == 3 {
+			return fmt.Errorf("handler failed")
+		}
+		return nil
+	})
+	assert.Error(t, err)
+
+	checkpoint, err := store.LoadCheckpoint()
+	assert.NoError(t, err)
+	assert.Equal(t, &Checkpoint{Height: 2, HeaderHash: testHash("a-2")}, checkpoint)
+}
+
+// TestFileCheckpointStore Ensures checkpoints survive a round trip through the file store
+func TestFileCheckpointStore(t *testing.T) {
+	store := &FileCheckpointStore{Path: filepath.Join(t.TempDir(), "checkpoint.json")}
+
+	checkpoint, err := store.LoadCheckpoint()
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	assert.NoError(t, store.SaveCheckpoint(&Checkpoint{Height: 10, HeaderHash: testHash("abc")}))
+	checkpoint, err = store.LoadCheckpoint()
+	assert.NoError(t, err)
+	assert.Equal(t, &Checkpoint{Height: 10, HeaderHash: testHash("abc")}, checkpoint)
+}
@@ -3,9 +3,12 @@ package rpc
 import (
 	"log"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/forks-lab/go-stai-libs/pkg/config"
 	"github.com/forks-lab/go-stai-libs/pkg/httpclient"
+	"github.com/forks-lab/go-stai-libs/pkg/profile"
 	"github.com/forks-lab/go-stai-libs/pkg/rpcinterface"
 	"github.com/forks-lab/go-stai-libs/pkg/types"
 	"github.com/forks-lab/go-stai-libs/pkg/websocketclient"
@@ -23,7 +26,17 @@ type Client struct {
 	HarvesterService *HarvesterService
 	CrawlerService   *CrawlerService
 
+	handlersLock      sync.RWMutex
+	listenOnce        sync.Once
 	websocketHandlers []rpcinterface.WebsocketResponseHandler
+
+	// eventHandlers are the typed handlers registered with the On* methods, keyed by origin and command
+	eventHandlers   map[eventKey][]eventHandler
+	unknownHandlers []rpcinterface.WebsocketResponseHandler
+	eventStreams    []*EventStream
+
+	subscriptionsLock sync.Mutex
+	subscriptions     map[string]bool
 }
 
 // ConnectionMode specifies the method used to connect to the server (HTTP or Websocket)
@@ -39,13 +52,20 @@ const (
 
 // NewClient returns a new RPC Client
 func NewClient(connectionMode ConnectionMode, options ...rpcinterface.ClientOptionFunc) (*Client, error) {
-	cfg, err := config.GetStaiConfig()
+	return NewClientForProfile(profile.Default, connectionMode, options...)
+}
+
+// NewClientForProfile returns a new RPC Client for the installation of the chain described by the profile
+func NewClientForProfile(p *profile.Profile, connectionMode ConnectionMode, options ...rpcinterface.ClientOptionFunc) (*Client, error) {
+	cfg, err := config.GetConfigForProfile(p)
 	if err != nil {
 		return nil, err
 	}
 
 	c := &Client{
-		config: cfg,
+		config:        cfg,
+		eventHandlers: map[eventKey][]eventHandler{},
+		subscriptions: map[string]bool{},
 	}
 
 	var activeClient rpcinterface.Client
@@ -79,6 +99,17 @@ func (c *Client) Do(req *rpcinterface.Request, v interface{}) (*http.Response, e
 	return c.activeClient.Do(req, v)
 }
 
+// SetConfigReloadInterval sets how often the active client checks config.yaml and the SSL files for changes
+// An interval of 0 stops checking. Returns an error if the active client does not support reloading
+func (c *Client) SetConfigReloadInterval(interval time.Duration) error {
+	reloader, ok := c.activeClient.(rpcinterface.ConfigReloader)
+	if !ok {
+		return errConfigReloadUnsupported
+	}
+	reloader.SetConfigReloadInterval(interval)
+	return nil
+}
+
 // The following has a bunch of methods that are currently only used for the websocket implementation
 
 // SubscribeSelf subscribes to responses to requests from this service
@@ -89,8 +120,9 @@ func (c *Client) SubscribeSelf() error {
 
 // Subscribe adds a subscription to events from a particular service
 // This is currently only useful for websocket mode
+// Subscribing to the same service more than once is a no-op
 func (c *Client) Subscribe(service string) error {
-	return c.activeClient.Subscribe(service)
+	return c.subscribeOnce(service)
 }
 
 // AddHandler adds a handler function to call when a message is received over the websocket
@@ -98,17 +130,26 @@ func (c *Client) Subscribe(service string) error {
 // This will run in the background, and allow other things to happen in the foreground
 // while ListenSync will take over the foreground process
 func (c *Client) AddHandler(handler rpcinterface.WebsocketResponseHandler) error {
+	c.handlersLock.Lock()
 	c.websocketHandlers = append(c.websocketHandlers, handler)
+	c.handlersLock.Unlock()
 
-	go func() {
-		err := c.ListenSync(c.handlerProxy)
-		if err != nil {
-			log.Printf("Error calling ListenSync: %s\n", err.Error())
-		}
-	}()
+	c.listenInBackground()
 	return nil
 }
 
+// listenInBackground starts the background listener that feeds handlerProxy, if it is not already running
+func (c *Client) listenInBackground() {
+	c.listenOnce.Do(func() {
+		go func() {
+			err := c.ListenSync(c.handlerProxy)
+			if err != nil {
+				log.Printf("Error calling ListenSync: %s\n", err.Error())
+			}
+		}()
+	})
+}
+
 // AddDisconnectHandler the function to call when the client is disconnected
 func (c *Client) AddDisconnectHandler(onDisconnect rpcinterface.DisconnectHandler) {
 	c.activeClient.AddDisconnectHandler(onDisconnect)
@@ -120,11 +161,44 @@ func (c *Client) AddReconnectHandler(onReconnect rpcinterface.ReconnectHandler)
 }
 
 // handlerProxy matches the websocketRespHandler signature to send requests back to any registered handlers
-// Here to support multiple handlers for a single event in the future
+// Generic handlers receive every response, while typed handlers only receive the events they registered for
 func (c *Client) handlerProxy(resp *types.WebsocketResponse, err error) {
-	for _, handler := range c.websocketHandlers {
+	// Copy the handlers so they are free to register more handlers while being called
+	c.handlersLock.RLock()
+	websocketHandlers := c.websocketHandlers
+	unknownHandlers := c.unknownHandlers
+	eventStreams := c.eventStreams
+	var handlers []eventHandler
+	var ok bool
+	if err == nil && resp != nil {
+		handlers, ok = c.eventHandlers[eventKey{origin: resp.Origin, command: resp.Command}]
+	}
+	c.handlersLock.RUnlock()
+
+	for _, handler := range websocketHandlers {
 		handler(resp, err)
 	}
+
+	if err != nil || resp == nil {
+		return
+	}
+
+	for _, stream := range eventStreams {
+		stream.send(resp)
+	}
+
+	if !ok {
+		for _, handler := range unknownHandlers {
+			handler(resp, nil)
+		}
+		return
+	}
+
+	for _, handler := range handlers {
+		if err := handler(resp.Data); err != nil {
+			log.Printf("Error decoding `%s` event from %s: %s\n", resp.Command, resp.Origin, err.Error())
+		}
+	}
 }
 
 // ListenSync Listens for async responses over the connection in a synchronous fashion, blocking anything else
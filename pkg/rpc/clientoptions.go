@@ -1,12 +1,16 @@
 package rpc
 
 import (
+	"errors"
 	"net/url"
 	"time"
 
 	"github.com/forks-lab/go-stai-libs/pkg/rpcinterface"
 )
 
+// errConfigReloadUnsupported is returned when the client can't reload its config
+var errConfigReloadUnsupported = errors.New("client does not support reloading config")
+
 // WithBaseURL sets the host for RPC requests
 func WithBaseURL(url *url.URL) rpcinterface.ClientOptionFunc {
 	return func(c rpcinterface.Client) error {
@@ -23,3 +27,19 @@ func WithCache(validTime time.Duration) rpcinterface.ClientOptionFunc {
 		return nil
 	}
 }
+
+// WithConfigReload checks config.yaml and the SSL files it references for changes every interval
+// When they change, ports and certificates are reloaded without having to create a new client
+// If unset, config is only read when the client is created
+// Returns an error if the client does not implement rpcinterface.ConfigReloader
+func WithConfigReload(interval time.Duration) rpcinterface.ClientOptionFunc {
+	return func(c rpcinterface.Client) error {
+		reloader, ok := c.(rpcinterface.ConfigReloader)
+		if !ok {
+			return errConfigReloadUnsupported
+		}
+		reloader.SetConfigReloadInterval(interval)
+
+		return nil
+	}
+}
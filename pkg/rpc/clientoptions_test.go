@@ -0,0 +1,33 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeReloadingClient records the config reload interval it was given
+type fakeReloadingClient struct {
+	fakeChainClient
+	interval time.Duration
+}
+
+func (f *fakeReloadingClient) SetConfigReloadInterval(interval time.Duration) {
+	f.interval = interval
+}
+
+// TestWithConfigReload Ensures config reloading is only set on clients that support it
+func TestWithConfigReload(t *testing.T) {
+	reloading := &fakeReloadingClient{}
+	assert.NoError(t, WithConfigReload(time.Minute)(reloading))
+	assert.Equal(t, time.Minute, reloading.interval)
+
+	client := &Client{activeClient: reloading}
+	assert.NoError(t, client.SetConfigReloadInterval(0))
+	assert.Equal(t, time.Duration(0), reloading.interval)
+
+	assert.ErrorIs(t, WithConfigReload(time.Minute)(&fakeChainClient{}), errConfigReloadUnsupported)
+	client = &Client{activeClient: &fakeChainClient{}}
+	assert.ErrorIs(t, client.SetConfigReloadInterval(time.Minute), errConfigReloadUnsupported)
+}
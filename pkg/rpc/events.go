@@ -0,0 +1,203 @@
+package rpc
+
+import (
+	"encoding/json"
+
+	"github.com/forks-lab/go-stai-libs/pkg/rpcinterface"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// originForService returns the origin used in websocket events sent by the service
+func (c *Client) originForService(service rpcinterface.ServiceType) string {
+	return service.WebsocketName(c.config.Profile())
+}
+
+// serviceMetrics is the service to subscribe to in order to receive events useful for metrics/monitoring
+const serviceMetrics = "metrics"
+
+// eventKey identifies a websocket event by the service that sent it and the command
+type eventKey struct {
+	origin  string
+	command string
+}
+
+// eventHandler decodes the data for a websocket event and passes it along to a typed handler
+type eventHandler func(data json.RawMessage) error
+
+// OnBlock registers a handler for `block` events from the full node
+func (c *Client) OnBlock(handler func(*types.BlockEvent)) error {
+	return c.registerEventHandler(rpcinterface.ServiceFullNode, "block", func(data json.RawMessage) error {
+		event := &types.BlockEvent{}
+		if err := json.Unmarshal(data, event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// OnSignagePoint registers a handler for `signage_point` events from the full node
+func (c *Client) OnSignagePoint(handler func(*types.SignagePointEvent)) error {
+	return c.registerEventHandler(rpcinterface.ServiceFullNode, "signage_point", func(data json.RawMessage) error {
+		event := &types.SignagePointEvent{}
+		if err := json.Unmarshal(data, event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// OnBlockchainState registers a handler for `get_blockchain_state` events from the full node
+func (c *Client) OnBlockchainState(handler func(*types.WebsocketBlockchainState)) error {
+	return c.registerEventHandler(rpcinterface.ServiceFullNode, "get_blockchain_state", func(data json.RawMessage) error {
+		event := &types.WebsocketBlockchainState{}
+		if err := json.Unmarshal(data, event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// OnFarmerProof registers a handler for `proof` events from the farmer
+func (c *Client) OnFarmerProof(handler func(*types.EventFarmerProof)) error {
+	return c.registerEventHandler(rpcinterface.ServiceFarmer, "proof", func(data json.RawMessage) error {
+		event := &types.EventFarmerProof{}
+		if err := json.Unmarshal(data, event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// OnFarmerSubmittedPartial registers a handler for `submitted_partial` events from the farmer
+func (c *Client) OnFarmerSubmittedPartial(handler func(*types.EventFarmerSubmittedPartial)) error {
+	return c.registerEventHandler(rpcinterface.ServiceFarmer, "submitted_partial", func(data json.RawMessage) error {
+		event := &types.EventFarmerSubmittedPartial{}
+		if err := json.Unmarshal(data, event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// OnHarvesterFarmingInfo registers a handler for `farming_info` events from the harvester
+func (c *Client) OnHarvesterFarmingInfo(handler func(*types.EventHarvesterFarmingInfo)) error {
+	return c.registerEventHandler(rpcinterface.ServiceHarvester, "farming_info", func(data json.RawMessage) error {
+		event := &types.EventHarvesterFarmingInfo{}
+		if err := json.Unmarshal(data, event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// OnCoinAdded registers a handler for `coin_added` events from the wallet
+func (c *Client) OnCoinAdded(handler func(*types.CoinAddedEvent)) error {
+	return c.registerEventHandler(rpcinterface.ServiceWallet, "coin_added", func(data json.RawMessage) error {
+		event := &types.CoinAddedEvent{}
+		if err := json.Unmarshal(data, event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// OnFinishedPoT registers a handler for `finished_pot` events from the timelord
+func (c *Client) OnFinishedPoT(handler func(*types.FinishedPoTEvent)) error {
+	return c.registerEventHandler(rpcinterface.ServiceTimelord, "finished_pot", func(data json.RawMessage) error {
+		event := &types.FinishedPoTEvent{}
+		if err := json.Unmarshal(data, event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// OnNewCompactProof registers a handler for `new_compact_proof` events from the timelord
+func (c *Client) OnNewCompactProof(handler func(*types.NewCompactProofEvent)) error {
+	return c.registerEventHandler(rpcinterface.ServiceTimelord, "new_compact_proof", func(data json.RawMessage) error {
+		event := &types.NewCompactProofEvent{}
+		if err := json.Unmarshal(data, event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// OnSkippingPeak registers a handler for `skipping_peak` events from the timelord
+func (c *Client) OnSkippingPeak(handler func(*types.SkippingPeakEvent)) error {
+	return c.registerEventHandler(rpcinterface.ServiceTimelord, "skipping_peak", func(data json.RawMessage) error {
+		event := &types.SkippingPeakEvent{}
+		if err := json.Unmarshal(data, event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// OnNewPeak registers a handler for `new_peak` events from the timelord
+func (c *Client) OnNewPeak(handler func(*types.NewPeakEvent)) error {
+	return c.registerEventHandler(rpcinterface.ServiceTimelord, "new_peak", func(data json.RawMessage) error {
+		event := &types.NewPeakEvent{}
+		if err := json.Unmarshal(data, event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// OnUnknownEvent registers a handler for any event that does not have a typed handler registered
+// Handlers added with AddHandler still receive every event, regardless of typed handlers
+func (c *Client) OnUnknownEvent(handler rpcinterface.WebsocketResponseHandler) error {
+	c.handlersLock.Lock()
+	c.unknownHandlers = append(c.unknownHandlers, handler)
+	c.handlersLock.Unlock()
+
+	c.listenInBackground()
+	return nil
+}
+
+// registerEventHandler adds the handler for the event, subscribes to the metrics service, and ensures
+// the background listener is running
+func (c *Client) registerEventHandler(service rpcinterface.ServiceType, command string, handler eventHandler) error {
+	err := c.subscribeOnce(serviceMetrics)
+	if err != nil {
+		return err
+	}
+
+	key := eventKey{origin: c.originForService(service), command: command}
+	c.handlersLock.Lock()
+	c.eventHandlers[key] = append(c.eventHandlers[key], handler)
+	c.handlersLock.Unlock()
+
+	c.listenInBackground()
+	return nil
+}
+
+// subscribeOnce subscribes to the service, unless this client already subscribed to it
+func (c *Client) subscribeOnce(service string) error {
+	c.subscriptionsLock.Lock()
+	defer c.subscriptionsLock.Unlock()
+
+	if c.subscriptions[service] {
+		return nil
+	}
+
+	err := c.activeClient.Subscribe(service)
+	if err != nil {
+		return err
+	}
+	c.subscriptions[service] = true
+
+	return nil
+}
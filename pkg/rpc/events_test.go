@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/config"
+	"github.com/forks-lab/go-stai-libs/pkg/rpcinterface"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// fakeEventClient counts the subscriptions made through it
+type fakeEventClient struct {
+	fakeChainClient
+
+	lock          sync.Mutex
+	subscriptions map[string]int
+}
+
+func (f *fakeEventClient) Subscribe(service string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.subscriptions[service]++
+	return nil
+}
+
+func newFakeEventClient() (*Client, *fakeEventClient) {
+	active := &fakeEventClient{subscriptions: map[string]int{}}
+	return &Client{
+		config:        &config.StaiConfig{},
+		activeClient:  active,
+		eventHandlers: map[eventKey][]eventHandler{},
+		subscriptions: map[string]bool{},
+	}, active
+}
+
+// TestTypedEventHandlers Ensures each websocket event is decoded and routed to the handler registered for it
+func TestTypedEventHandlers(t *testing.T) {
+	client, _ := newFakeEventClient()
+	received := map[string]interface{}{}
+
+	assert.NoError(t, client.OnBlock(func(e *types.BlockEvent) { received["block"] = e.Height }))
+	assert.NoError(t, client.OnSignagePoint(func(e *types.SignagePointEvent) { received["signage_point"] = e.Success }))
+	assert.NoError(t, client.OnBlockchainState(func(e *types.WebsocketBlockchainState) {
+		received["get_blockchain_state"] = e.BlockchainState.Difficulty
+	}))
+	assert.NoError(t, client.OnFarmerProof(func(e *types.EventFarmerProof) { received["proof"] = e.PassedFilter }))
+	assert.NoError(t, client.OnFarmerSubmittedPartial(func(e *types.EventFarmerSubmittedPartial) {
+		received["submitted_partial"] = e.PoolURL
+	}))
+	assert.NoError(t, client.OnHarvesterFarmingInfo(func(e *types.EventHarvesterFarmingInfo) {
+		received["farming_info"] = e.TotalPlots
+	}))
+	assert.NoError(t, client.OnCoinAdded(func(e *types.CoinAddedEvent) { received["coin_added"] = e.WalletID }))
+	assert.NoError(t, client.OnFinishedPoT(func(e *types.FinishedPoTEvent) { received["finished_pot"] = e.IterationsNeeded }))
+	assert.NoError(t, client.OnNewCompactProof(func(e *types.NewCompactProofEvent) { received["new_compact_proof"] = e.Height }))
+	assert.NoError(t, client.OnSkippingPeak(func(e *types.SkippingPeakEvent) { received["skipping_peak"] = e.Height }))
+	assert.NoError(t, client.OnNewPeak(func(e *types.NewPeakEvent) { received["new_peak"] = e.Height }))
+
+	var unknown []string
+	assert.NoError(t, client.OnUnknownEvent(func(resp *types.WebsocketResponse, err error) {
+		unknown = append(unknown, resp.Command)
+	}))
+
+	tests := []struct {
+		service  rpcinterface.ServiceType
+		command  string
+		data     string
+		expected interface{}
+	}{
+		{rpcinterface.ServiceFullNode, "block", `{"height":12}`, uint32(12)},
+		{rpcinterface.ServiceFullNode, "signage_point", `{"success":true}`, true},
+		{rpcinterface.ServiceFullNode, "get_blockchain_state", `{"blockchain_state":{"difficulty":1024}}`, uint64(1024)},
+		{rpcinterface.ServiceFarmer, "proof", `{"passed_filter":true}`, true},
+		{rpcinterface.ServiceFarmer, "submitted_partial", `{"pool_url":"https://pool.example"}`, "https://pool.example"},
+		{rpcinterface.ServiceHarvester, "farming_info", `{"total_plots":42}`, uint64(42)},
+		{rpcinterface.ServiceWallet, "coin_added", `{"wallet_id":3}`, uint32(3)},
+		{rpcinterface.ServiceTimelord, "finished_pot", `{"iterations_needed":500}`, uint64(500)},
+		{rpcinterface.ServiceTimelord, "new_compact_proof", `{"height":7}`, uint32(7)},
+		{rpcinterface.ServiceTimelord, "skipping_peak", `{"height":8}`, uint32(8)},
+		{rpcinterface.ServiceTimelord, "new_peak", `{"height":9}`, uint32(9)},
+	}
+	for _, test := range tests {
+		client.handlerProxy(&types.WebsocketResponse{
+			Origin:  client.originForService(test.service),
+			Command: test.command,
+			Data:    []byte(test.data),
+		}, nil)
+		assert.Equal(t, test.expected, received[test.command], test.command)
+	}
+	assert.Empty(t, unknown)
+
+	// Events from a different service, or without a typed handler, go to the unknown handlers
+	client.handlerProxy(&types.WebsocketResponse{Origin: client.originForService(rpcinterface.ServiceWallet), Command: "block"}, nil)
+	client.handlerProxy(&types.WebsocketResponse{Origin: client.originForService(rpcinterface.ServiceFullNode), Command: "sync_state"}, nil)
+	assert.Equal(t, []string{"block", "sync_state"}, unknown)
+}
+
+// TestEventHandlersSubscribeOnce Ensures registering many handlers only subscribes to the metrics service once
+func TestEventHandlersSubscribeOnce(t *testing.T) {
+	client, active := newFakeEventClient()
+
+	assert.NoError(t, client.OnBlock(func(*types.BlockEvent) {}))
+	assert.NoError(t, client.OnBlock(func(*types.BlockEvent) {}))
+	assert.NoError(t, client.OnNewPeak(func(*types.NewPeakEvent) {}))
+	assert.NoError(t, client.OnCoinAdded(func(*types.CoinAddedEvent) {}))
+	assert.NoError(t, client.Subscribe(serviceMetrics))
+
+	assert.Equal(t, map[string]int{serviceMetrics: 1}, active.subscriptions)
+}
@@ -0,0 +1,292 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/forks-lab/go-stai-libs/pkg/profile"
+	"github.com/forks-lab/go-stai-libs/pkg/rpcinterface"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// ErrEventStreamOverflow is the reason an event stream using OverflowDisconnect was closed when its buffer filled up
+var ErrEventStreamOverflow = errors.New("event stream buffer is full")
+
+// DefaultEventBufferSize is the number of events buffered by an event stream unless configured otherwise
+const DefaultEventBufferSize = 100
+
+// Event is a single event received over the websocket
+type Event struct {
+	Origin    string
+	Command   string
+	RequestID string
+	Data      json.RawMessage
+}
+
+// Decode unmarshals the event data into v
+func (e *Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// EventFilter selects which events are delivered to an event stream
+// Empty lists match everything
+type EventFilter struct {
+	Services []rpcinterface.ServiceType
+	Commands []string
+}
+
+// matches returns true if the event should be delivered according to the filter
+// Service names in the event origin are resolved using the profile
+func (f EventFilter) matches(p *profile.Profile, resp *types.WebsocketResponse) bool {
+	if len(f.Services) > 0 {
+		found := false
+		for _, service := range f.Services {
+			if service.WebsocketName(p) == resp.Origin {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(f.Commands) > 0 {
+		for _, command := range f.Commands {
+			if command == resp.Command {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// OverflowPolicy determines what an event stream does when an event arrives and the buffer is full
+type OverflowPolicy uint8
+
+const (
+	// OverflowDropOldest discards the oldest buffered event to make room for the new event
+	OverflowDropOldest OverflowPolicy = iota
+
+	// OverflowBlock waits until the consumer has made room for the event
+	// This stalls all websocket traffic until there is room in the buffer
+	OverflowBlock
+
+	// OverflowDisconnect closes the stream. Err will return ErrEventStreamOverflow
+	OverflowDisconnect
+)
+
+// EventStreamOptionFunc can be used to customize a new event stream
+type EventStreamOptionFunc func(stream *EventStream)
+
+// WithEventBufferSize sets how many events are buffered before the overflow policy applies
+func WithEventBufferSize(size int) EventStreamOptionFunc {
+	return func(stream *EventStream) {
+		stream.bufferSize = size
+	}
+}
+
+// WithOverflowPolicy sets what happens when an event arrives and the buffer is full
+// Defaults to OverflowDropOldest
+func WithOverflowPolicy(policy OverflowPolicy) EventStreamOptionFunc {
+	return func(stream *EventStream) {
+		stream.policy = policy
+	}
+}
+
+// EventStream delivers websocket events that match a filter on a channel
+type EventStream struct {
+	// dropped is first to guarantee 64-bit alignment for atomic operations
+	dropped uint64
+
+	// C is the channel events are delivered on. It is closed when the stream ends
+	C <-chan Event
+
+	ch         chan Event
+	ctx        context.Context
+	profile    *profile.Profile
+	filter     EventFilter
+	bufferSize int
+	policy     OverflowPolicy
+
+	lock   sync.Mutex
+	closed bool
+	done   chan struct{}
+	err    error
+
+	onClose func(stream *EventStream)
+}
+
+// Dropped returns the number of events that were discarded because the buffer was full
+func (s *EventStream) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Err returns the reason the stream was closed, or nil if it is still open
+func (s *EventStream) Err() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.err
+}
+
+// Events returns a channel of events received over the websocket that match the filter
+// The channel is closed when ctx is done. Use StreamEvents to find out how many events were dropped or why the
+// channel was closed
+func (c *Client) Events(ctx context.Context, filter EventFilter, options ...EventStreamOptionFunc) (<-chan Event, error) {
+	stream, err := c.StreamEvents(ctx, filter, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return stream.C, nil
+}
+
+// StreamEvents returns a stream of events received over the websocket that match the filter
+// The stream subscribes to the metrics service and is closed when ctx is done. Subscribe to
+// any other services the filter should receive events from before calling StreamEvents
+func (c *Client) StreamEvents(ctx context.Context, filter EventFilter, options ...EventStreamOptionFunc) (*EventStream, error) {
+	stream := newEventStream(ctx, c.config.Profile(), filter, options...)
+	stream.onClose = c.removeEventStream
+
+	err := c.subscribeOnce(serviceMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	c.handlersLock.Lock()
+	c.eventStreams = append(c.eventStreams, stream)
+	c.handlersLock.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			stream.close(ctx.Err())
+		case <-stream.done:
+		}
+	}()
+
+	c.listenInBackground()
+	return stream, nil
+}
+
+func newEventStream(ctx context.Context, p *profile.Profile, filter EventFilter, options ...EventStreamOptionFunc) *EventStream {
+	stream := &EventStream{
+		ctx:        ctx,
+		profile:    p,
+		filter:     filter,
+		bufferSize: DefaultEventBufferSize,
+		policy:     OverflowDropOldest,
+		done:       make(chan struct{}),
+	}
+
+	for _, fn := range options {
+		if fn == nil {
+			continue
+		}
+		fn(stream)
+	}
+
+	if stream.bufferSize < 1 {
+		stream.bufferSize = 1
+	}
+	stream.ch = make(chan Event, stream.bufferSize)
+	stream.C = stream.ch
+
+	return stream
+}
+
+// removeEventStream stops delivering events to the stream
+func (c *Client) removeEventStream(stream *EventStream) {
+	c.handlersLock.Lock()
+	defer c.handlersLock.Unlock()
+
+	streams := make([]*EventStream, 0, len(c.eventStreams))
+	for _, existing := range c.eventStreams {
+		if existing != stream {
+			streams = append(streams, existing)
+		}
+	}
+	c.eventStreams = streams
+}
+
+// send delivers the response to the stream if it matches the filter, applying the overflow policy as needed
+func (s *EventStream) send(resp *types.WebsocketResponse) {
+	if !s.filter.matches(s.profile, resp) {
+		return
+	}
+
+	event := Event{
+		Origin:    resp.Origin,
+		Command:   resp.Command,
+		RequestID: resp.RequestID,
+		Data:      resp.Data,
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.ch <- event:
+		return
+	default:
+	}
+
+	switch s.policy {
+	case OverflowBlock:
+		select {
+		case s.ch <- event:
+		case <-s.ctx.Done():
+			atomic.AddUint64(&s.dropped, 1)
+		}
+	case OverflowDisconnect:
+		atomic.AddUint64(&s.dropped, 1)
+		s.closeLocked(ErrEventStreamOverflow)
+	default:
+		for {
+			select {
+			case s.ch <- event:
+				return
+			default:
+			}
+
+			select {
+			case <-s.ch:
+				atomic.AddUint64(&s.dropped, 1)
+			default:
+			}
+		}
+	}
+}
+
+// close closes the stream, if it is not already closed
+func (s *EventStream) close(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.closeLocked(err)
+}
+
+func (s *EventStream) closeLocked(err error) {
+	if s.closed {
+		return
+	}
+
+	s.closed = true
+	s.err = err
+	close(s.ch)
+	close(s.done)
+
+	if s.onClose != nil {
+		s.onClose(s)
+	}
+}
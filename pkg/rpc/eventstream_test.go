@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/profile"
+	"github.com/forks-lab/go-stai-libs/pkg/rpcinterface"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+func blockEvent(height string) *types.WebsocketResponse {
+	return &types.WebsocketResponse{
+		Origin:  "stai_full_node",
+		Command: "block",
+		Data:    []byte(`{"height":` + height + `}`),
+	}
+}
+
+// TestEventFilter Ensures only events matching the service and command are delivered
+func TestEventFilter(t *testing.T) {
+	stream := newEventStream(context.Background(), profile.STAI, EventFilter{
+		Services: []rpcinterface.ServiceType{rpcinterface.ServiceFullNode},
+		Commands: []string{"block"},
+	})
+
+	stream.send(blockEvent("1"))
+	stream.send(&types.WebsocketResponse{Origin: "stai_full_node", Command: "signage_point"})
+	stream.send(&types.WebsocketResponse{Origin: "stai_wallet", Command: "block"})
+
+	assert.Len(t, stream.C, 1)
+	event := <-stream.C
+	assert.Equal(t, "block", event.Command)
+
+	block := &types.BlockEvent{}
+	assert.NoError(t, event.Decode(block))
+	assert.Equal(t, uint32(1), block.Height)
+}
+
+// TestEventStreamDropOldest Ensures the oldest events are discarded when the buffer is full
+func TestEventStreamDropOldest(t *testing.T) {
+	stream := newEventStream(context.Background(), profile.STAI, EventFilter{}, WithEventBufferSize(2))
+
+	stream.send(blockEvent("1"))
+	stream.send(blockEvent("2"))
+	stream.send(blockEvent("3"))
+
+	assert.Equal(t, uint64(1), stream.Dropped())
+	assert.Equal(t, `{"height":2}`, string((<-stream.C).Data))
+	assert.Equal(t, `{"height":3}`, string((<-stream.C).Data))
+	assert.NoError(t, stream.Err())
+}
+
+// TestEventStreamDisconnect Ensures the stream is closed when the buffer is full
+func TestEventStreamDisconnect(t *testing.T) {
+	stream := newEventStream(context.Background(), profile.STAI, EventFilter{}, WithEventBufferSize(1), WithOverflowPolicy(OverflowDisconnect))
+
+	stream.send(blockEvent("1"))
+	stream.send(blockEvent("2"))
+	stream.send(blockEvent("3"))
+
+	assert.Equal(t, uint64(1), stream.Dropped())
+	assert.ErrorIs(t, stream.Err(), ErrEventStreamOverflow)
+
+	_, ok := <-stream.C
+	assert.True(t, ok)
+	_, ok = <-stream.C
+	assert.False(t, ok)
+}
+
+// TestEventStreamBlock Ensures a blocked send is delivered once the consumer makes room
+func TestEventStreamBlock(t *testing.T) {
+	stream := newEventStream(context.Background(), profile.STAI, EventFilter{}, WithEventBufferSize(1), WithOverflowPolicy(OverflowBlock))
+
+	stream.send(blockEvent("1"))
+
+	sent := make(chan struct{})
+	go func() {
+		stream.send(blockEvent("2"))
+		close(sent)
+	}()
+
+	select {
+	case <-sent:
+		t.Fatal("send did not block with a full buffer")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	assert.Equal(t, `{"height":1}`, string((<-stream.C).Data))
+	<-sent
+	assert.Equal(t, `{"height":2}`, string((<-stream.C).Data))
+	assert.Equal(t, uint64(0), stream.Dropped())
+}
+
+// TestClientEvents Ensures Events delivers matching events on the returned channel and closes it with the context
+func TestClientEvents(t *testing.T) {
+	client, _ := newFakeEventClient()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, err := client.Events(ctx, EventFilter{Commands: []string{"block"}})
+	assert.NoError(t, err)
+
+	client.handlerProxy(blockEvent("5"), nil)
+	event := <-events
+	assert.Equal(t, "block", event.Command)
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed when the context was cancelled")
+	}
+}
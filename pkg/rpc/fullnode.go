@@ -1,6 +1,8 @@
 package rpc
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/forks-lab/go-stai-libs/pkg/rpcinterface"
@@ -99,15 +101,48 @@ func (s *FullNodeService) GetBlock(opts *GetBlockOptions) (*GetBlockResponse, *h
 }
 
 // GetBlocksOptions options for get_blocks rpc call
+// End is exclusive
 type GetBlocksOptions struct {
-	Start int `json:"start"`
-	End   int `json:"end"`
+	Start          int  `json:"start"`
+	End            int  `json:"end"`
+	ExcludeReorged bool `json:"exclude_reorged,omitempty"` // not required, omits blocks that were reorged out of the chain while fetching
 }
 
 // GetBlocksResponse response for get_blocks rpc call
 type GetBlocksResponse struct {
 	Success bool               `json:"success"`
 	Blocks  []*types.FullBlock `json:"blocks"`
+	// HeaderHashes are the header hashes the node returned alongside each block, at the same index as Blocks
+	// nil if the node did not return a header hash for every block
+	HeaderHashes []types.Bytes32 `json:"-"`
+}
+
+// UnmarshalJSON unmarshals the blocks along with the header_hash the node adds to each of them
+func (r *GetBlocksResponse) UnmarshalJSON(data []byte) error {
+	type getBlocksResponse GetBlocksResponse
+	if err := json.Unmarshal(data, (*getBlocksResponse)(r)); err != nil {
+		return err
+	}
+
+	hashes := struct {
+		Blocks []*struct {
+			HeaderHash *types.Bytes32 `json:"header_hash"`
+		} `json:"blocks"`
+	}{}
+	if err := json.Unmarshal(data, &hashes); err != nil {
+		return err
+	}
+
+	r.HeaderHashes = make([]types.Bytes32, 0, len(hashes.Blocks))
+	for _, block := range hashes.Blocks {
+		if block == nil || block.HeaderHash == nil {
+			r.HeaderHashes = nil
+			return nil
+		}
+		r.HeaderHashes = append(r.HeaderHashes, *block.HeaderHash)
+	}
+
+	return nil
 }
 
 // GetBlocks full_node->get_blocks RPC method
@@ -126,6 +161,57 @@ func (s *FullNodeService) GetBlocks(opts *GetBlocksOptions) (*GetBlocksResponse,
 	return r, resp, nil
 }
 
+// GetBlockRecordOptions options for get_block_record rpc call
+type GetBlockRecordOptions struct {
+	HeaderHash string `json:"header_hash"`
+}
+
+// GetBlockRecord full_node->get_block_record RPC method
+// Returns the block record even if the block is no longer part of the main chain
+func (s *FullNodeService) GetBlockRecord(opts *GetBlockRecordOptions) (*GetBlockRecordResponse, *http.Response, error) {
+	request, err := s.NewRequest("get_block_record", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetBlockRecordResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetBlockRecordsOptions options for get_block_records rpc call
+// End is exclusive
+type GetBlockRecordsOptions struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// GetBlockRecordsResponse response for get_block_records rpc call
+type GetBlockRecordsResponse struct {
+	Success      bool                 `json:"success"`
+	BlockRecords []*types.BlockRecord `json:"block_records"`
+}
+
+// GetBlockRecords full_node->get_block_records RPC method
+func (s *FullNodeService) GetBlockRecords(opts *GetBlockRecordsOptions) (*GetBlockRecordsResponse, *http.Response, error) {
+	request, err := s.NewRequest("get_block_records", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetBlockRecordsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
 // GetBlockCountMetricsResponse response for get_block_count_metrics rpc call
 type GetBlockCountMetricsResponse struct {
 	Success bool                     `json:"success"`
@@ -190,9 +276,12 @@ func (s *FullNodeService) GetBlockByHeight(opts *GetBlockByHeightOptions) (*GetB
 	if err != nil {
 		return nil, resp, err
 	}
+	if record == nil || record.BlockRecord == nil {
+		return nil, resp, fmt.Errorf("block record at height %d was not found", opts.BlockHeight)
+	}
 
 	request, err := s.NewRequest("get_block", GetBlockOptions{
-		HeaderHash: record.BlockRecord.HeaderHash,
+		HeaderHash: record.BlockRecord.HeaderHash.String(),
 	})
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,16 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetBlockByHeightMissingRecord Ensures an unsuccessful block record response is an error rather than a panic
+func TestGetBlockByHeightMissingRecord(t *testing.T) {
+	service := newFakeFullNodeService(10)
+
+	block, _, err := service.GetBlockByHeight(&GetBlockByHeightOptions{BlockHeight: 20})
+	assert.EqualError(t, err, "block record at height 20 was not found")
+	assert.Nil(t, block)
+}
@@ -0,0 +1,34 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/forks-lab/go-stai-libs/pkg/clvm"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// GetBlockSpendsFromGenerator decodes the coin spends in the transactions generator of the block, without calling
+// get_block_spends. Generators referenced by the block are fetched with get_block_record_by_height and get_block
+// Returns nil if the block is not a transaction block
+func (s *FullNodeService) GetBlockSpendsFromGenerator(block *types.FullBlock, options clvm.RunOptions) ([]*clvm.BlockSpend, error) {
+	if block.TransactionsGenerator == nil {
+		return nil, nil
+	}
+
+	refs := make([]types.SerializedProgram, 0, len(block.TransactionsGeneratorRefList))
+	for _, height := range block.TransactionsGeneratorRefList {
+		ref, _, err := s.GetBlockByHeight(&GetBlockByHeightOptions{BlockHeight: int(height)})
+		if err != nil {
+			return nil, err
+		}
+		if ref == nil || ref.Block == nil {
+			return nil, fmt.Errorf("referenced block at height %d was not found", height)
+		}
+		if ref.Block.TransactionsGenerator == nil {
+			return nil, fmt.Errorf("referenced block at height %d has no transactions generator", height)
+		}
+		refs = append(refs, *ref.Block.TransactionsGenerator)
+	}
+
+	return clvm.DecodeGenerator(*block.TransactionsGenerator, refs, options)
+}
@@ -45,3 +45,163 @@ func (s *HarvesterService) GetPlots() (*HarvesterGetPlotsResponse, *http.Respons
 
 	return p, resp, nil
 }
+
+// HarvesterGetPlotDirectoriesResponse get_plot_directories response format
+type HarvesterGetPlotDirectoriesResponse struct {
+	Success     bool     `json:"success"`
+	Directories []string `json:"directories"`
+}
+
+// GetPlotDirectories returns the directories the harvester is scanning for plots
+func (s *HarvesterService) GetPlotDirectories() (*HarvesterGetPlotDirectoriesResponse, *http.Response, error) {
+	request, err := s.NewRequest("get_plot_directories", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &HarvesterGetPlotDirectoriesResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// HarvesterPlotDirectoryOptions options for add_plot_directory and remove_plot_directory
+type HarvesterPlotDirectoryOptions struct {
+	Dirname string `json:"dirname"`
+}
+
+// HarvesterSuccessResponse response format for harvester rpcs that only report success
+type HarvesterSuccessResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
+
+// AddPlotDirectory adds a directory to the list of directories the harvester scans for plots
+func (s *HarvesterService) AddPlotDirectory(opts *HarvesterPlotDirectoryOptions) (*HarvesterSuccessResponse, *http.Response, error) {
+	request, err := s.NewRequest("add_plot_directory", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &HarvesterSuccessResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// RemovePlotDirectory removes a directory from the list of directories the harvester scans for plots
+func (s *HarvesterService) RemovePlotDirectory(opts *HarvesterPlotDirectoryOptions) (*HarvesterSuccessResponse, *http.Response, error) {
+	request, err := s.NewRequest("remove_plot_directory", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &HarvesterSuccessResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// RefreshPlots triggers a refresh of the plots in all plot directories
+func (s *HarvesterService) RefreshPlots() (*HarvesterSuccessResponse, *http.Response, error) {
+	request, err := s.NewRequest("refresh_plots", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &HarvesterSuccessResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// HarvesterDeletePlotOptions options for delete_plot
+type HarvesterDeletePlotOptions struct {
+	Filename string `json:"filename"`
+}
+
+// DeletePlot deletes a plot file from disk
+func (s *HarvesterService) DeletePlot(opts *HarvesterDeletePlotOptions) (*HarvesterSuccessResponse, *http.Response, error) {
+	request, err := s.NewRequest("delete_plot", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &HarvesterSuccessResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// HarvesterGetConfigResponse get_harvester_config response format
+type HarvesterGetConfigResponse struct {
+	Success                         bool   `json:"success"`
+	UseGPUHarvesting                bool   `json:"use_gpu_harvesting"`
+	GPUIndex                        int    `json:"gpu_index"`
+	EnforceGPUIndex                 bool   `json:"enforce_gpu_index"`
+	DisableCPUAffinity              bool   `json:"disable_cpu_affinity"`
+	ParallelDecompressorCount       int    `json:"parallel_decompressor_count"`
+	DecompressorThreadCount         int    `json:"decompressor_thread_count"`
+	RecursivePlotScan               bool   `json:"recursive_plot_scan"`
+	RefreshParameterIntervalSeconds uint32 `json:"refresh_parameter_interval_seconds"`
+}
+
+// GetHarvesterConfig returns the harvester's plot and decompression settings
+func (s *HarvesterService) GetHarvesterConfig() (*HarvesterGetConfigResponse, *http.Response, error) {
+	request, err := s.NewRequest("get_harvester_config", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &HarvesterGetConfigResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// HarvesterUpdateConfigOptions options for update_harvester_config
+// Only the values that are set will be updated
+type HarvesterUpdateConfigOptions struct {
+	UseGPUHarvesting                *bool   `json:"use_gpu_harvesting,omitempty"`
+	GPUIndex                        *int    `json:"gpu_index,omitempty"`
+	EnforceGPUIndex                 *bool   `json:"enforce_gpu_index,omitempty"`
+	DisableCPUAffinity              *bool   `json:"disable_cpu_affinity,omitempty"`
+	ParallelDecompressorCount       *int    `json:"parallel_decompressor_count,omitempty"`
+	DecompressorThreadCount         *int    `json:"decompressor_thread_count,omitempty"`
+	RecursivePlotScan               *bool   `json:"recursive_plot_scan,omitempty"`
+	RefreshParameterIntervalSeconds *uint32 `json:"refresh_parameter_interval_seconds,omitempty"`
+}
+
+// UpdateHarvesterConfig updates the harvester's plot and decompression settings
+func (s *HarvesterService) UpdateHarvesterConfig(opts *HarvesterUpdateConfigOptions) (*HarvesterSuccessResponse, *http.Response, error) {
+	request, err := s.NewRequest("update_harvester_config", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &HarvesterSuccessResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"github.com/forks-lab/go-stai-libs/pkg/rpcinterface"
@@ -231,10 +232,14 @@ func (s *WalletService) GetTransaction(opts *GetWalletTransactionOptions) (*GetW
 
 // SendTransactionOptions represents the options for send_transaction
 type SendTransactionOptions struct {
-	WalletID uint32 `json:"wallet_id"`
-	Amount   uint64 `json:"amount"`
-	Address  string `json:"address"`
-	Fee      uint64 `json:"fee"`
+	WalletID       uint32   `json:"wallet_id"`
+	Amount         uint64   `json:"amount"`
+	Address        string   `json:"address"`
+	Fee            uint64   `json:"fee"`
+	Memos          []string `json:"memos,omitempty"`            // not required
+	MinCoinAmount  uint64   `json:"min_coin_amount,omitempty"`  // not required
+	MaxCoinAmount  uint64   `json:"max_coin_amount,omitempty"`  // not required
+	ExcludeCoinIDs []string `json:"exclude_coin_ids,omitempty"` // not required
 }
 
 // SendTransactionResponse represents the response from send_transaction
@@ -260,12 +265,88 @@ func (s *WalletService) SendTransaction(opts *SendTransactionOptions) (*SendTran
 	return r, resp, nil
 }
 
+// SendTransactionMultiOptions represents the options for send_transaction_multi
+type SendTransactionMultiOptions struct {
+	WalletID            uint32                      `json:"wallet_id"`
+	Additions           []*types.Addition           `json:"additions"`
+	Fee                 uint64                      `json:"fee"`                            // not required
+	Coins               []*types.Coin               `json:"coins,omitempty"`                // not required
+	CoinAnnouncements   []*types.CoinAnnouncement   `json:"coin_announcements,omitempty"`   // not required
+	PuzzleAnnouncements []*types.PuzzleAnnouncement `json:"puzzle_announcements,omitempty"` // not required
+	MinCoinAmount       uint64                      `json:"min_coin_amount,omitempty"`      // not required
+	MaxCoinAmount       uint64                      `json:"max_coin_amount,omitempty"`      // not required
+	ExcludeCoinIDs      []string                    `json:"exclude_coin_ids,omitempty"`     // not required
+}
+
+// SendTransactionMultiResponse represents the response from send_transaction_multi
+type SendTransactionMultiResponse struct {
+	Success       bool                    `json:"success"`
+	TransactionID string                  `json:"transaction_id"`
+	Transaction   types.TransactionRecord `json:"transaction"`
+}
+
+// SendTransactionMulti sends a single transaction paying out to multiple additions
+func (s *WalletService) SendTransactionMulti(opts *SendTransactionMultiOptions) (*SendTransactionMultiResponse, *http.Response, error) {
+	request, err := s.NewRequest("send_transaction_multi", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SendTransactionMultiResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CreateSignedTransactionOptions represents the options for create_signed_transaction
+type CreateSignedTransactionOptions struct {
+	WalletID            *uint32                     `json:"wallet_id,omitempty"` // not required, defaults to the standard wallet
+	Additions           []*types.Addition           `json:"additions"`
+	Fee                 uint64                      `json:"fee"`                            // not required
+	Coins               []*types.Coin               `json:"coins,omitempty"`                // not required
+	CoinAnnouncements   []*types.CoinAnnouncement   `json:"coin_announcements,omitempty"`   // not required
+	PuzzleAnnouncements []*types.PuzzleAnnouncement `json:"puzzle_announcements,omitempty"` // not required
+	MinCoinAmount       uint64                      `json:"min_coin_amount,omitempty"`      // not required
+	MaxCoinAmount       uint64                      `json:"max_coin_amount,omitempty"`      // not required
+	ExcludeCoinIDs      []string                    `json:"exclude_coin_ids,omitempty"`     // not required
+}
+
+// CreateSignedTransactionResponse represents the response from create_signed_transaction
+type CreateSignedTransactionResponse struct {
+	Success   bool                       `json:"success"`
+	SignedTX  types.TransactionRecord    `json:"signed_tx"`
+	SignedTXs []*types.TransactionRecord `json:"signed_txs"`
+}
+
+// CreateSignedTransaction creates a signed transaction without pushing it to the mempool
+func (s *WalletService) CreateSignedTransaction(opts *CreateSignedTransactionOptions) (*CreateSignedTransactionResponse, *http.Response, error) {
+	request, err := s.NewRequest("create_signed_transaction", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CreateSignedTransactionResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
 // CatSpendOptions represents the options for cat_spend
 type CatSpendOptions struct {
-	WalletID uint32 `json:"wallet_id"`
-	Amount   uint64 `json:"amount"`
-	Address  string `json:"inner_address"`
-	Fee      uint64 `json:"fee"`
+	WalletID       uint32   `json:"wallet_id"`
+	Amount         uint64   `json:"amount"`
+	Address        string   `json:"inner_address"`
+	Fee            uint64   `json:"fee"`
+	Memos          []string `json:"memos,omitempty"`            // not required
+	MinCoinAmount  uint64   `json:"min_coin_amount,omitempty"`  // not required
+	MaxCoinAmount  uint64   `json:"max_coin_amount,omitempty"`  // not required
+	ExcludeCoinIDs []string `json:"exclude_coin_ids,omitempty"` // not required
 }
 
 // CatSpendResponse represents the response from cat_spend
@@ -334,7 +415,9 @@ func (s *WalletService) MintNFT(opts *MintNFTOptions) (*MintNFTResponse, *http.R
 
 // GetNFTsOptions represents the options for nft_get_nfts
 type GetNFTsOptions struct {
-	WalletID uint32 `json:"wallet_id"`
+	WalletID   uint32 `json:"wallet_id"`
+	StartIndex uint32 `json:"start_index,omitempty"` // not required
+	Num        uint32 `json:"num,omitempty"`         // not required, the number of NFTs to return starting at StartIndex
 }
 
 // GetNFTsResponse represents the response from nft_get_nfts
@@ -450,3 +533,1398 @@ func (s *WalletService) NFTAddURI(opts *NFTAddURIOptions) (*NFTAddURIResponse, *
 
 	return r, resp, nil
 }
+
+// CreateOfferForIDsOptions represents the options for create_offer_for_ids
+type CreateOfferForIDsOptions struct {
+	// Offer maps wallet IDs (or asset IDs) to amounts. Negative amounts are offered, positive amounts are requested
+	Offer         map[string]int64                 `json:"offer"`
+	Fee           uint64                           `json:"fee"`                       // not required
+	DriverDict    map[string]*types.OfferAssetInfo `json:"driver_dict,omitempty"`     // not required
+	ValidateOnly  bool                             `json:"validate_only,omitempty"`   // not required
+	MinCoinAmount uint64                           `json:"min_coin_amount,omitempty"` // not required
+	MaxCoinAmount uint64                           `json:"max_coin_amount,omitempty"` // not required
+}
+
+// CreateOfferForIDsResponse represents the response from create_offer_for_ids
+type CreateOfferForIDsResponse struct {
+	Success     bool               `json:"success"`
+	Offer       string             `json:"offer"`
+	TradeRecord *types.TradeRecord `json:"trade_record"`
+}
+
+// CreateOfferForIDs creates a new offer
+func (s *WalletService) CreateOfferForIDs(opts *CreateOfferForIDsOptions) (*CreateOfferForIDsResponse, *http.Response, error) {
+	request, err := s.NewRequest("create_offer_for_ids", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CreateOfferForIDsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetOfferSummaryOptions represents the options for get_offer_summary
+type GetOfferSummaryOptions struct {
+	Offer    string `json:"offer"`
+	Advanced bool   `json:"advanced,omitempty"` // not required
+}
+
+// GetOfferSummaryResponse represents the response from get_offer_summary
+type GetOfferSummaryResponse struct {
+	Success bool                `json:"success"`
+	ID      string              `json:"id"`
+	Summary *types.OfferSummary `json:"summary"`
+}
+
+// GetOfferSummary returns a summary of the assets offered and requested in an offer
+func (s *WalletService) GetOfferSummary(opts *GetOfferSummaryOptions) (*GetOfferSummaryResponse, *http.Response, error) {
+	request, err := s.NewRequest("get_offer_summary", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetOfferSummaryResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CheckOfferValidityOptions represents the options for check_offer_validity
+type CheckOfferValidityOptions struct {
+	Offer string `json:"offer"`
+}
+
+// CheckOfferValidityResponse represents the response from check_offer_validity
+type CheckOfferValidityResponse struct {
+	Success bool   `json:"success"`
+	Valid   bool   `json:"valid"`
+	ID      string `json:"id"`
+}
+
+// CheckOfferValidity checks if the coins in an offer are still unspent
+func (s *WalletService) CheckOfferValidity(opts *CheckOfferValidityOptions) (*CheckOfferValidityResponse, *http.Response, error) {
+	request, err := s.NewRequest("check_offer_validity", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CheckOfferValidityResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// TakeOfferOptions represents the options for take_offer
+type TakeOfferOptions struct {
+	Offer         string `json:"offer"`
+	Fee           uint64 `json:"fee"`                       // not required
+	MinCoinAmount uint64 `json:"min_coin_amount,omitempty"` // not required
+	MaxCoinAmount uint64 `json:"max_coin_amount,omitempty"` // not required
+}
+
+// TakeOfferResponse represents the response from take_offer
+type TakeOfferResponse struct {
+	Success     bool               `json:"success"`
+	TradeRecord *types.TradeRecord `json:"trade_record"`
+}
+
+// TakeOffer accepts an offer
+func (s *WalletService) TakeOffer(opts *TakeOfferOptions) (*TakeOfferResponse, *http.Response, error) {
+	request, err := s.NewRequest("take_offer", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &TakeOfferResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetOfferOptions represents the options for get_offer
+type GetOfferOptions struct {
+	TradeID      string `json:"trade_id"`
+	FileContents bool   `json:"file_contents,omitempty"` // not required
+}
+
+// GetOfferResponse represents the response from get_offer
+type GetOfferResponse struct {
+	Success     bool               `json:"success"`
+	TradeRecord *types.TradeRecord `json:"trade_record"`
+	Offer       *string            `json:"offer"` // Only present when FileContents is requested
+}
+
+// GetOffer returns a single offer by trade ID
+func (s *WalletService) GetOffer(opts *GetOfferOptions) (*GetOfferResponse, *http.Response, error) {
+	request, err := s.NewRequest("get_offer", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetOfferResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetAllOffersOptions represents the options for get_all_offers
+type GetAllOffersOptions struct {
+	Start              *int   `json:"start,omitempty"`
+	End                *int   `json:"end,omitempty"`
+	ExcludeMyOffers    bool   `json:"exclude_my_offers,omitempty"`
+	ExcludeTakenOffers bool   `json:"exclude_taken_offers,omitempty"`
+	IncludeCompleted   bool   `json:"include_completed,omitempty"`
+	SortKey            string `json:"sort_key,omitempty"`
+	Reverse            bool   `json:"reverse,omitempty"`
+	FileContents       bool   `json:"file_contents,omitempty"`
+}
+
+// GetAllOffersResponse represents the response from get_all_offers
+type GetAllOffersResponse struct {
+	Success      bool                 `json:"success"`
+	TradeRecords []*types.TradeRecord `json:"trade_records"`
+	Offers       []string             `json:"offers"` // Only present when FileContents is requested
+}
+
+// GetAllOffers returns all offers in the wallet
+func (s *WalletService) GetAllOffers(opts *GetAllOffersOptions) (*GetAllOffersResponse, *http.Response, error) {
+	request, err := s.NewRequest("get_all_offers", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetAllOffersResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetOffersCountResponse represents the response from get_offers_count
+type GetOffersCountResponse struct {
+	Success          bool `json:"success"`
+	Total            int  `json:"total"`
+	MyOffersCount    int  `json:"my_offers_count"`
+	TakenOffersCount int  `json:"taken_offers_count"`
+}
+
+// GetOffersCount returns the number of offers in the wallet
+func (s *WalletService) GetOffersCount() (*GetOffersCountResponse, *http.Response, error) {
+	request, err := s.NewRequest("get_offers_count", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetOffersCountResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CancelOfferOptions represents the options for cancel_offer
+type CancelOfferOptions struct {
+	TradeID string `json:"trade_id"`
+	// Secure cancels the offer on chain by spending the coins. Otherwise, the offer is only cancelled locally
+	Secure bool   `json:"secure"`
+	Fee    uint64 `json:"fee"` // not required
+}
+
+// CancelOfferResponse represents the response from cancel_offer
+type CancelOfferResponse struct {
+	Success bool `json:"success"`
+}
+
+// CancelOffer cancels a single offer
+func (s *WalletService) CancelOffer(opts *CancelOfferOptions) (*CancelOfferResponse, *http.Response, error) {
+	request, err := s.NewRequest("cancel_offer", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CancelOfferResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CancelOffersOptions represents the options for cancel_offers
+type CancelOffersOptions struct {
+	Secure    bool   `json:"secure"`
+	BatchFee  uint64 `json:"batch_fee"`            // not required
+	BatchSize int    `json:"batch_size,omitempty"` // not required
+	CancelAll bool   `json:"cancel_all,omitempty"` // not required
+	AssetID   string `json:"asset_id,omitempty"`   // not required, only cancels offers involving this asset
+}
+
+// CancelOffersResponse represents the response from cancel_offers
+type CancelOffersResponse struct {
+	Success bool `json:"success"`
+}
+
+// CancelOffers cancels offers in batches
+func (s *WalletService) CancelOffers(opts *CancelOffersOptions) (*CancelOffersResponse, *http.Response, error) {
+	request, err := s.NewRequest("cancel_offers", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CancelOffersResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CATWalletMode is the mode used when creating a new CAT wallet
+type CATWalletMode string
+
+const (
+	// CATWalletModeNew issues a brand new CAT
+	CATWalletModeNew CATWalletMode = "new"
+
+	// CATWalletModeExisting creates a wallet for a CAT that already exists
+	CATWalletModeExisting CATWalletMode = "existing"
+)
+
+// CreateNewCATWalletOptions represents the options for create_new_wallet with the cat_wallet type
+type CreateNewCATWalletOptions struct {
+	Mode    CATWalletMode `json:"mode"`
+	Name    string        `json:"name,omitempty"`     // not required
+	Amount  uint64        `json:"amount,omitempty"`   // required when issuing a new CAT
+	Fee     uint64        `json:"fee"`                // not required
+	AssetID string        `json:"asset_id,omitempty"` // required for existing CATs
+}
+
+// CreateNewCATWalletResponse represents the response from create_new_wallet with the cat_wallet type
+type CreateNewCATWalletResponse struct {
+	Success  bool             `json:"success"`
+	Type     types.WalletType `json:"type"`
+	AssetID  string           `json:"asset_id"`
+	WalletID uint32           `json:"wallet_id"`
+}
+
+// CreateNewCATWallet creates a new CAT wallet, either issuing a new CAT or tracking an existing asset
+func (s *WalletService) CreateNewCATWallet(opts *CreateNewCATWalletOptions) (*CreateNewCATWalletResponse, *http.Response, error) {
+	request, err := s.NewRequest("create_new_wallet", struct {
+		WalletType string `json:"wallet_type"`
+		*CreateNewCATWalletOptions
+	}{"cat_wallet", opts})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CreateNewCATWalletResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CatGetNameOptions represents the options for cat_get_name
+type CatGetNameOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// CatGetNameResponse represents the response from cat_get_name
+type CatGetNameResponse struct {
+	Success  bool   `json:"success"`
+	WalletID uint32 `json:"wallet_id"`
+	Name     string `json:"name"`
+}
+
+// CatGetName returns the name of a CAT wallet
+func (s *WalletService) CatGetName(opts *CatGetNameOptions) (*CatGetNameResponse, *http.Response, error) {
+	request, err := s.NewRequest("cat_get_name", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CatGetNameResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CatSetNameOptions represents the options for cat_set_name
+type CatSetNameOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+	Name     string `json:"name"`
+}
+
+// CatSetNameResponse represents the response from cat_set_name
+type CatSetNameResponse struct {
+	Success  bool   `json:"success"`
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// CatSetName sets the name of a CAT wallet
+func (s *WalletService) CatSetName(opts *CatSetNameOptions) (*CatSetNameResponse, *http.Response, error) {
+	request, err := s.NewRequest("cat_set_name", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CatSetNameResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CatGetAssetIDOptions represents the options for cat_get_asset_id
+type CatGetAssetIDOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// CatGetAssetIDResponse represents the response from cat_get_asset_id
+type CatGetAssetIDResponse struct {
+	Success  bool   `json:"success"`
+	WalletID uint32 `json:"wallet_id"`
+	AssetID  string `json:"asset_id"`
+}
+
+// CatGetAssetID returns the asset ID of the CAT tracked by a CAT wallet
+func (s *WalletService) CatGetAssetID(opts *CatGetAssetIDOptions) (*CatGetAssetIDResponse, *http.Response, error) {
+	request, err := s.NewRequest("cat_get_asset_id", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CatGetAssetIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// CatAssetIDToNameOptions represents the options for cat_asset_id_to_name
+type CatAssetIDToNameOptions struct {
+	AssetID string `json:"asset_id"`
+}
+
+// CatAssetIDToNameResponse represents the response from cat_asset_id_to_name
+type CatAssetIDToNameResponse struct {
+	Success  bool    `json:"success"`
+	WalletID *uint32 `json:"wallet_id"` // nil if there is no wallet for the asset
+	Name     string  `json:"name"`
+}
+
+// CatAssetIDToName returns the name of a CAT, and the wallet ID tracking it if one exists
+func (s *WalletService) CatAssetIDToName(opts *CatAssetIDToNameOptions) (*CatAssetIDToNameResponse, *http.Response, error) {
+	request, err := s.NewRequest("cat_asset_id_to_name", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CatAssetIDToNameResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetCATListResponse represents the response from get_cat_list
+type GetCATListResponse struct {
+	Success bool         `json:"success"`
+	CATList []*types.CAT `json:"cat_list"`
+}
+
+// GetCATList returns the list of CATs known to the wallet by default
+func (s *WalletService) GetCATList() (*GetCATListResponse, *http.Response, error) {
+	request, err := s.NewRequest("get_cat_list", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetCATListResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetStrayCATsResponse represents the response from get_stray_cats
+type GetStrayCATsResponse struct {
+	Success   bool              `json:"success"`
+	StrayCATs []*types.StrayCAT `json:"stray_cats"`
+}
+
+// GetStrayCATs returns CATs that have been received, but do not have a wallet yet
+func (s *WalletService) GetStrayCATs() (*GetStrayCATsResponse, *http.Response, error) {
+	request, err := s.NewRequest("get_stray_cats", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetStrayCATsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDWalletType is the type of DID wallet to create
+type DIDWalletType string
+
+const (
+	// DIDWalletTypeNew creates a brand new DID
+	DIDWalletTypeNew DIDWalletType = "new"
+
+	// DIDWalletTypeRecovery recovers a DID from backup data
+	DIDWalletTypeRecovery DIDWalletType = "recovery"
+)
+
+// CreateNewDIDWalletOptions represents the options for create_new_wallet with the did_wallet type
+type CreateNewDIDWalletOptions struct {
+	DIDType              DIDWalletType     `json:"did_type"`
+	BackupDIDs           []string          `json:"backup_dids"`              // not required, sent as an empty list when nil
+	NumOfBackupIDsNeeded uint64            `json:"num_of_backup_ids_needed"` // not required
+	Amount               uint64            `json:"amount,omitempty"`         // required for new DIDs
+	Metadata             map[string]string `json:"metadata,omitempty"`       // not required
+	WalletName           string            `json:"wallet_name,omitempty"`    // not required
+	Fee                  uint64            `json:"fee"`                      // not required
+	BackupData           string            `json:"backup_data,omitempty"`    // required for recovery
+}
+
+// CreateNewDIDWalletResponse represents the response from create_new_wallet with the did_wallet type
+type CreateNewDIDWalletResponse struct {
+	Success  bool             `json:"success"`
+	Type     types.WalletType `json:"type"`
+	MyDID    string           `json:"my_did"`
+	WalletID uint32           `json:"wallet_id"`
+}
+
+// CreateNewDIDWallet creates a new DID wallet
+func (s *WalletService) CreateNewDIDWallet(opts *CreateNewDIDWalletOptions) (*CreateNewDIDWalletResponse, *http.Response, error) {
+	if opts == nil {
+		opts = &CreateNewDIDWalletOptions{}
+	}
+
+	// The wallet iterates over backup_dids, so it must be sent as an empty list rather than null
+	withBackups := *opts
+	if withBackups.BackupDIDs == nil {
+		withBackups.BackupDIDs = []string{}
+	}
+
+	request, err := s.NewRequest("create_new_wallet", struct {
+		WalletType string `json:"wallet_type"`
+		*CreateNewDIDWalletOptions
+	}{"did_wallet", &withBackups})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CreateNewDIDWalletResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDGetDIDOptions represents the options for did_get_did
+type DIDGetDIDOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// DIDGetDIDResponse represents the response from did_get_did
+type DIDGetDIDResponse struct {
+	Success  bool   `json:"success"`
+	WalletID uint32 `json:"wallet_id"`
+	MyDID    string `json:"my_did"`
+	CoinID   string `json:"coin_id"`
+}
+
+// DIDGetDID returns the DID for a DID wallet
+func (s *WalletService) DIDGetDID(opts *DIDGetDIDOptions) (*DIDGetDIDResponse, *http.Response, error) {
+	request, err := s.NewRequest("did_get_did", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDGetDIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDGetInfoOptions represents the options for did_get_info
+type DIDGetInfoOptions struct {
+	CoinID string `json:"coin_id"` // DID ID or coin ID
+	Latest *bool  `json:"latest,omitempty"`
+}
+
+// DIDGetInfoResponse represents the response from did_get_info
+type DIDGetInfoResponse struct {
+	Success bool `json:"success"`
+	types.DIDInfo
+}
+
+// DIDGetInfo returns information about a DID
+func (s *WalletService) DIDGetInfo(opts *DIDGetInfoOptions) (*DIDGetInfoResponse, *http.Response, error) {
+	request, err := s.NewRequest("did_get_info", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDGetInfoResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDSetWalletNameOptions represents the options for did_set_wallet_name
+type DIDSetWalletNameOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+	Name     string `json:"name"`
+}
+
+// DIDSetWalletNameResponse represents the response from did_set_wallet_name
+type DIDSetWalletNameResponse struct {
+	Success  bool   `json:"success"`
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// DIDSetWalletName sets the name of a DID wallet
+func (s *WalletService) DIDSetWalletName(opts *DIDSetWalletNameOptions) (*DIDSetWalletNameResponse, *http.Response, error) {
+	request, err := s.NewRequest("did_set_wallet_name", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDSetWalletNameResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDGetWalletNameOptions represents the options for did_get_wallet_name
+type DIDGetWalletNameOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// DIDGetWalletNameResponse represents the response from did_get_wallet_name
+type DIDGetWalletNameResponse struct {
+	Success  bool   `json:"success"`
+	WalletID uint32 `json:"wallet_id"`
+	Name     string `json:"name"`
+}
+
+// DIDGetWalletName returns the name of a DID wallet
+func (s *WalletService) DIDGetWalletName(opts *DIDGetWalletNameOptions) (*DIDGetWalletNameResponse, *http.Response, error) {
+	request, err := s.NewRequest("did_get_wallet_name", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDGetWalletNameResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDUpdateMetadataOptions represents the options for did_update_metadata
+type DIDUpdateMetadataOptions struct {
+	WalletID uint32            `json:"wallet_id"`
+	Metadata map[string]string `json:"metadata"`
+	Fee      uint64            `json:"fee"` // not required
+}
+
+// DIDUpdateMetadataResponse represents the response from did_update_metadata
+type DIDUpdateMetadataResponse struct {
+	Success     bool              `json:"success"`
+	WalletID    uint32            `json:"wallet_id"`
+	SpendBundle types.SpendBundle `json:"spend_bundle"`
+}
+
+// DIDUpdateMetadata replaces the metadata of a DID
+func (s *WalletService) DIDUpdateMetadata(opts *DIDUpdateMetadataOptions) (*DIDUpdateMetadataResponse, *http.Response, error) {
+	request, err := s.NewRequest("did_update_metadata", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDUpdateMetadataResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDGetMetadataOptions represents the options for did_get_metadata
+type DIDGetMetadataOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// DIDGetMetadataResponse represents the response from did_get_metadata
+type DIDGetMetadataResponse struct {
+	Success  bool              `json:"success"`
+	WalletID uint32            `json:"wallet_id"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// DIDGetMetadata returns the metadata of a DID
+func (s *WalletService) DIDGetMetadata(opts *DIDGetMetadataOptions) (*DIDGetMetadataResponse, *http.Response, error) {
+	request, err := s.NewRequest("did_get_metadata", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDGetMetadataResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDTransferDIDOptions represents the options for did_transfer_did
+type DIDTransferDIDOptions struct {
+	WalletID         uint32 `json:"wallet_id"`
+	InnerAddress     string `json:"inner_address"`
+	Fee              uint64 `json:"fee"`                          // not required
+	WithRecoveryInfo *bool  `json:"with_recovery_info,omitempty"` // not required, defaults to true
+}
+
+// DIDTransferDIDResponse represents the response from did_transfer_did
+type DIDTransferDIDResponse struct {
+	Success       bool                    `json:"success"`
+	TransactionID string                  `json:"transaction_id"`
+	Transaction   types.TransactionRecord `json:"transaction"`
+}
+
+// DIDTransferDID transfers a DID to another address
+func (s *WalletService) DIDTransferDID(opts *DIDTransferDIDOptions) (*DIDTransferDIDResponse, *http.Response, error) {
+	request, err := s.NewRequest("did_transfer_did", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDTransferDIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDUpdateRecoveryIDsOptions represents the options for did_update_recovery_ids
+type DIDUpdateRecoveryIDsOptions struct {
+	WalletID                 uint32   `json:"wallet_id"`
+	NewList                  []string `json:"new_list"`
+	NumVerificationsRequired *uint64  `json:"num_verifications_required,omitempty"` // not required, defaults to the length of NewList
+}
+
+// DIDUpdateRecoveryIDsResponse represents the response from did_update_recovery_ids
+type DIDUpdateRecoveryIDsResponse struct {
+	Success bool `json:"success"`
+}
+
+// DIDUpdateRecoveryIDs updates the list of DIDs that can be used to recover a DID
+func (s *WalletService) DIDUpdateRecoveryIDs(opts *DIDUpdateRecoveryIDsOptions) (*DIDUpdateRecoveryIDsResponse, *http.Response, error) {
+	request, err := s.NewRequest("did_update_recovery_ids", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDUpdateRecoveryIDsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DIDGetRecoveryListOptions represents the options for did_get_recovery_list
+type DIDGetRecoveryListOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// DIDGetRecoveryListResponse represents the response from did_get_recovery_list
+type DIDGetRecoveryListResponse struct {
+	Success      bool     `json:"success"`
+	WalletID     uint32   `json:"wallet_id"`
+	RecoveryList []string `json:"recovery_list"`
+	NumRequired  uint64   `json:"num_required"`
+}
+
+// DIDGetRecoveryList returns the list of DIDs that can be used to recover a DID
+func (s *WalletService) DIDGetRecoveryList(opts *DIDGetRecoveryListOptions) (*DIDGetRecoveryListResponse, *http.Response, error) {
+	request, err := s.NewRequest("did_get_recovery_list", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DIDGetRecoveryListResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTCountNFTsOptions represents the options for nft_count_nfts
+type NFTCountNFTsOptions struct {
+	WalletID *uint32 `json:"wallet_id,omitempty"` // not required, counts NFTs in all wallets when nil
+}
+
+// NFTCountNFTsResponse represents the response from nft_count_nfts
+type NFTCountNFTsResponse struct {
+	Success  bool    `json:"success"`
+	WalletID *uint32 `json:"wallet_id"`
+	Count    uint64  `json:"count"`
+}
+
+// NFTCountNFTs returns the number of NFTs in a wallet
+func (s *WalletService) NFTCountNFTs(opts *NFTCountNFTsOptions) (*NFTCountNFTsResponse, *http.Response, error) {
+	request, err := s.NewRequest("nft_count_nfts", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTCountNFTsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTGetByDIDOptions represents the options for nft_get_by_did
+type NFTGetByDIDOptions struct {
+	DIDID string `json:"did_id,omitempty"` // not required, returns the NFT wallet without a DID when empty
+}
+
+// NFTGetByDIDResponse represents the response from nft_get_by_did
+type NFTGetByDIDResponse struct {
+	Success  bool   `json:"success"`
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// NFTGetByDID returns the NFT wallet ID for a DID
+func (s *WalletService) NFTGetByDID(opts *NFTGetByDIDOptions) (*NFTGetByDIDResponse, *http.Response, error) {
+	request, err := s.NewRequest("nft_get_by_did", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTGetByDIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTSetNFTDIDOptions represents the options for nft_set_nft_did
+type NFTSetNFTDIDOptions struct {
+	WalletID  uint32 `json:"wallet_id"`
+	DIDID     string `json:"did_id"`
+	NFTCoinID string `json:"nft_coin_id"`
+	Fee       uint64 `json:"fee"` // not required
+}
+
+// NFTSetNFTDIDResponse represents the response from nft_set_nft_did
+type NFTSetNFTDIDResponse struct {
+	Success     bool              `json:"success"`
+	WalletID    uint32            `json:"wallet_id"`
+	SpendBundle types.SpendBundle `json:"spend_bundle"`
+}
+
+// NFTSetNFTDID sets the DID that owns an NFT
+func (s *WalletService) NFTSetNFTDID(opts *NFTSetNFTDIDOptions) (*NFTSetNFTDIDResponse, *http.Response, error) {
+	request, err := s.NewRequest("nft_set_nft_did", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTSetNFTDIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTGetWalletDIDOptions represents the options for nft_get_wallet_did
+type NFTGetWalletDIDOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// NFTGetWalletDIDResponse represents the response from nft_get_wallet_did
+type NFTGetWalletDIDResponse struct {
+	Success bool    `json:"success"`
+	DIDID   *string `json:"did_id"` // nil if the wallet is not associated with a DID
+}
+
+// NFTGetWalletDID returns the DID associated with an NFT wallet
+func (s *WalletService) NFTGetWalletDID(opts *NFTGetWalletDIDOptions) (*NFTGetWalletDIDResponse, *http.Response, error) {
+	request, err := s.NewRequest("nft_get_wallet_did", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTGetWalletDIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTGetWalletsWithDIDsResponse represents the response from nft_get_wallets_with_dids
+type NFTGetWalletsWithDIDsResponse struct {
+	Success    bool                      `json:"success"`
+	NFTWallets []*types.NFTWalletWithDID `json:"nft_wallets"`
+}
+
+// NFTGetWalletsWithDIDs returns all NFT wallets that are associated with a DID
+func (s *WalletService) NFTGetWalletsWithDIDs() (*NFTGetWalletsWithDIDsResponse, *http.Response, error) {
+	request, err := s.NewRequest("nft_get_wallets_with_dids", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTGetWalletsWithDIDsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTSetNFTStatusOptions represents the options for nft_set_nft_status
+type NFTSetNFTStatusOptions struct {
+	WalletID      uint32 `json:"wallet_id"`
+	CoinID        string `json:"coin_id"`
+	InTransaction bool   `json:"in_transaction"`
+}
+
+// NFTSetNFTStatusResponse represents the response from nft_set_nft_status
+type NFTSetNFTStatusResponse struct {
+	Success bool `json:"success"`
+}
+
+// NFTSetNFTStatus sets whether an NFT is pending in a transaction
+func (s *WalletService) NFTSetNFTStatus(opts *NFTSetNFTStatusOptions) (*NFTSetNFTStatusResponse, *http.Response, error) {
+	request, err := s.NewRequest("nft_set_nft_status", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTSetNFTStatusResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTCalculateRoyaltiesOptions represents the options for nft_calculate_royalties
+type NFTCalculateRoyaltiesOptions struct {
+	RoyaltyAssets  []*types.NFTRoyaltyAsset  `json:"royalty_assets"`
+	FungibleAssets []*types.NFTFungibleAsset `json:"fungible_assets"`
+}
+
+// NFTCalculateRoyaltiesResponse represents the response from nft_calculate_royalties
+type NFTCalculateRoyaltiesResponse struct {
+	Success bool
+	Error   string
+	// Royalties maps each royalty asset to the payments owed for it
+	Royalties map[string][]*types.NFTRoyalty
+}
+
+// UnmarshalJSON unmarshals the royalties, which are returned as top level keys alongside success and error
+func (r *NFTCalculateRoyaltiesResponse) UnmarshalJSON(data []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	r.Royalties = map[string][]*types.NFTRoyalty{}
+	for key, value := range fields {
+		switch key {
+		case "success":
+			if err := json.Unmarshal(value, &r.Success); err != nil {
+				return err
+			}
+			continue
+		case "error":
+			if err := json.Unmarshal(value, &r.Error); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var royalties []*types.NFTRoyalty
+		if err := json.Unmarshal(value, &royalties); err != nil {
+			return err
+		}
+		r.Royalties[key] = royalties
+	}
+
+	return nil
+}
+
+// NFTCalculateRoyalties calculates the royalties owed when trading NFTs for fungible assets
+func (s *WalletService) NFTCalculateRoyalties(opts *NFTCalculateRoyaltiesOptions) (*NFTCalculateRoyaltiesResponse, *http.Response, error) {
+	request, err := s.NewRequest("nft_calculate_royalties", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTCalculateRoyaltiesResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// NFTMintBulkOptions represents the options for nft_mint_bulk
+type NFTMintBulkOptions struct {
+	WalletID          uint32                   `json:"wallet_id"`
+	MetadataList      []*types.NFTBulkMetadata `json:"metadata_list"`
+	RoyaltyAddress    string                   `json:"royalty_address,omitempty"`    // not required
+	RoyaltyPercentage uint32                   `json:"royalty_percentage,omitempty"` // not required
+	TargetList        []string                 `json:"target_list,omitempty"`        // not required
+	MintNumberStart   uint32                   `json:"mint_number_start,omitempty"`  // not required
+	MintTotal         uint32                   `json:"mint_total,omitempty"`         // not required
+	MintFromDID       bool                     `json:"mint_from_did,omitempty"`      // not required
+	Fee               uint64                   `json:"fee"`                          // not required
+}
+
+// NFTMintBulkResponse represents the response from nft_mint_bulk
+type NFTMintBulkResponse struct {
+	Success     bool              `json:"success"`
+	SpendBundle types.SpendBundle `json:"spend_bundle"`
+	NFTIDList   []string          `json:"nft_id_list"`
+}
+
+// NFTMintBulk mints many NFTs in a single spend bundle
+func (s *WalletService) NFTMintBulk(opts *NFTMintBulkOptions) (*NFTMintBulkResponse, *http.Response, error) {
+	request, err := s.NewRequest("nft_mint_bulk", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &NFTMintBulkResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// PoolWalletInitialTargetState the state a new pool wallet should start in
+type PoolWalletInitialTargetState struct {
+	State              string            `json:"state"` // SELF_POOLING or FARMING_TO_POOL
+	TargetPuzzleHash   *types.PuzzleHash `json:"target_puzzle_hash,omitempty"`
+	PoolURL            string            `json:"pool_url,omitempty"`
+	RelativeLockHeight uint32            `json:"relative_lock_height"`
+}
+
+// CreateNewPoolWalletOptions represents the options for create_new_wallet with the pool_wallet type
+type CreateNewPoolWalletOptions struct {
+	Mode                 string                        `json:"mode"` // Always "new"
+	InitialTargetState   *PoolWalletInitialTargetState `json:"initial_target_state"`
+	Fee                  uint64                        `json:"fee"`                               // not required
+	P2SingletonDelayedPH string                        `json:"p2_singleton_delayed_ph,omitempty"` // not required
+	P2SingletonDelayTime uint64                        `json:"p2_singleton_delay_time,omitempty"` // not required
+}
+
+// CreateNewPoolWalletResponse represents the response from create_new_wallet with the pool_wallet type
+type CreateNewPoolWalletResponse struct {
+	Success               bool                    `json:"success"`
+	TotalFee              uint64                  `json:"total_fee"`
+	Transaction           types.TransactionRecord `json:"transaction"`
+	LauncherID            types.Bytes32           `json:"launcher_id"`
+	P2SingletonPuzzleHash types.PuzzleHash        `json:"p2_singleton_puzzle_hash"`
+}
+
+// CreateNewPoolWallet creates a new pool wallet (plot NFT)
+func (s *WalletService) CreateNewPoolWallet(opts *CreateNewPoolWalletOptions) (*CreateNewPoolWalletResponse, *http.Response, error) {
+	request, err := s.NewRequest("create_new_wallet", struct {
+		WalletType string `json:"wallet_type"`
+		*CreateNewPoolWalletOptions
+	}{"pool_wallet", opts})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &CreateNewPoolWalletResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// PWJoinPoolOptions represents the options for pw_join_pool
+type PWJoinPoolOptions struct {
+	WalletID           uint32           `json:"wallet_id"`
+	TargetPuzzleHash   types.PuzzleHash `json:"target_puzzlehash"`
+	PoolURL            string           `json:"pool_url"`
+	RelativeLockHeight uint32           `json:"relative_lock_height"`
+	Fee                uint64           `json:"fee"` // not required
+}
+
+// PWJoinPoolResponse represents the response from pw_join_pool
+type PWJoinPoolResponse struct {
+	Success        bool                     `json:"success"`
+	TotalFee       uint64                   `json:"total_fee"`
+	Transaction    types.TransactionRecord  `json:"transaction"`
+	FeeTransaction *types.TransactionRecord `json:"fee_transaction"`
+}
+
+// PWJoinPool joins a pool wallet to a pool
+func (s *WalletService) PWJoinPool(opts *PWJoinPoolOptions) (*PWJoinPoolResponse, *http.Response, error) {
+	request, err := s.NewRequest("pw_join_pool", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &PWJoinPoolResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// PWSelfPoolOptions represents the options for pw_self_pool
+type PWSelfPoolOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+	Fee      uint64 `json:"fee"` // not required
+}
+
+// PWSelfPoolResponse represents the response from pw_self_pool
+type PWSelfPoolResponse struct {
+	Success        bool                     `json:"success"`
+	TotalFee       uint64                   `json:"total_fee"`
+	Transaction    types.TransactionRecord  `json:"transaction"`
+	FeeTransaction *types.TransactionRecord `json:"fee_transaction"`
+}
+
+// PWSelfPool leaves the current pool and starts self pooling
+func (s *WalletService) PWSelfPool(opts *PWSelfPoolOptions) (*PWSelfPoolResponse, *http.Response, error) {
+	request, err := s.NewRequest("pw_self_pool", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &PWSelfPoolResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// PWAbsorbRewardsOptions represents the options for pw_absorb_rewards
+type PWAbsorbRewardsOptions struct {
+	WalletID      uint32 `json:"wallet_id"`
+	Fee           uint64 `json:"fee"`                        // not required
+	MaxSpendsInTX uint32 `json:"max_spends_in_tx,omitempty"` // not required
+}
+
+// PWAbsorbRewardsResponse represents the response from pw_absorb_rewards
+type PWAbsorbRewardsResponse struct {
+	Success        bool                     `json:"success"`
+	State          *types.PoolWalletInfo    `json:"state"`
+	Transaction    types.TransactionRecord  `json:"transaction"`
+	FeeTransaction *types.TransactionRecord `json:"fee_transaction"`
+}
+
+// PWAbsorbRewards claims self pooling rewards into the wallet
+func (s *WalletService) PWAbsorbRewards(opts *PWAbsorbRewardsOptions) (*PWAbsorbRewardsResponse, *http.Response, error) {
+	request, err := s.NewRequest("pw_absorb_rewards", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &PWAbsorbRewardsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// PWStatusOptions represents the options for pw_status
+type PWStatusOptions struct {
+	WalletID uint32 `json:"wallet_id"`
+}
+
+// PWStatusResponse represents the response from pw_status
+type PWStatusResponse struct {
+	Success                 bool                       `json:"success"`
+	State                   *types.PoolWalletInfo      `json:"state"`
+	UnconfirmedTransactions []*types.TransactionRecord `json:"unconfirmed_transactions"`
+}
+
+// PWStatus returns the current state of a pool wallet
+func (s *WalletService) PWStatus(opts *PWStatusOptions) (*PWStatusResponse, *http.Response, error) {
+	request, err := s.NewRequest("pw_status", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &PWStatusResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// SignMessageByAddressOptions represents the options for sign_message_by_address
+type SignMessageByAddressOptions struct {
+	Address  string `json:"address"`
+	Message  string `json:"message"`
+	IsHex    bool   `json:"is_hex,omitempty"`    // not required
+	SafeMode *bool  `json:"safe_mode,omitempty"` // not required, defaults to true
+}
+
+// SignMessageByAddressResponse represents the response from sign_message_by_address
+type SignMessageByAddressResponse struct {
+	Success bool `json:"success"`
+	types.SignedMessage
+}
+
+// SignMessageByAddress signs a message with the key for an address in the wallet
+func (s *WalletService) SignMessageByAddress(opts *SignMessageByAddressOptions) (*SignMessageByAddressResponse, *http.Response, error) {
+	request, err := s.NewRequest("sign_message_by_address", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SignMessageByAddressResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// SignMessageByIDOptions represents the options for sign_message_by_id
+type SignMessageByIDOptions struct {
+	ID       string `json:"id"` // DID or NFT ID
+	Message  string `json:"message"`
+	IsHex    bool   `json:"is_hex,omitempty"`    // not required
+	SafeMode *bool  `json:"safe_mode,omitempty"` // not required, defaults to true
+}
+
+// SignMessageByIDResponse represents the response from sign_message_by_id
+type SignMessageByIDResponse struct {
+	Success bool `json:"success"`
+	types.SignedMessage
+}
+
+// SignMessageByID signs a message with the key of a DID or NFT in the wallet
+func (s *WalletService) SignMessageByID(opts *SignMessageByIDOptions) (*SignMessageByIDResponse, *http.Response, error) {
+	request, err := s.NewRequest("sign_message_by_id", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SignMessageByIDResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// VerifySignatureOptions represents the options for verify_signature
+type VerifySignatureOptions struct {
+	PubKey      types.G1Element   `json:"pubkey"`
+	Message     string            `json:"message"`
+	Signature   types.G2Element   `json:"signature"`
+	Address     string            `json:"address,omitempty"`      // not required, also checks that the pubkey matches the address
+	SigningMode types.SigningMode `json:"signing_mode,omitempty"` // not required
+}
+
+// VerifySignatureResponse represents the response from verify_signature
+type VerifySignatureResponse struct {
+	Success bool   `json:"success"`
+	IsValid bool   `json:"isValid"`
+	Error   string `json:"error"`
+}
+
+// VerifySignature verifies a signed message
+func (s *WalletService) VerifySignature(opts *VerifySignatureOptions) (*VerifySignatureResponse, *http.Response, error) {
+	request, err := s.NewRequest("verify_signature", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &VerifySignatureResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// SendNotificationOptions represents the options for send_notification
+type SendNotificationOptions struct {
+	Target  string `json:"target"`  // address to notify
+	Message string `json:"message"` // hex encoded
+	Amount  uint64 `json:"amount"`
+	Fee     uint64 `json:"fee"` // not required
+}
+
+// SendNotificationResponse represents the response from send_notification
+type SendNotificationResponse struct {
+	Success bool                    `json:"success"`
+	TX      types.TransactionRecord `json:"tx"`
+}
+
+// SendNotification sends an on chain notification to an address
+func (s *WalletService) SendNotification(opts *SendNotificationOptions) (*SendNotificationResponse, *http.Response, error) {
+	request, err := s.NewRequest("send_notification", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &SendNotificationResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// GetNotificationsOptions represents the options for get_notifications
+type GetNotificationsOptions struct {
+	IDs   []string `json:"ids,omitempty"` // not required
+	Start *int     `json:"start,omitempty"`
+	End   *int     `json:"end,omitempty"`
+}
+
+// GetNotificationsResponse represents the response from get_notifications
+type GetNotificationsResponse struct {
+	Success       bool                  `json:"success"`
+	Notifications []*types.Notification `json:"notifications"`
+}
+
+// GetNotifications returns notifications received by the wallet
+func (s *WalletService) GetNotifications(opts *GetNotificationsOptions) (*GetNotificationsResponse, *http.Response, error) {
+	request, err := s.NewRequest("get_notifications", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &GetNotificationsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
+
+// DeleteNotificationsOptions represents the options for delete_notifications
+type DeleteNotificationsOptions struct {
+	IDs []string `json:"ids,omitempty"` // not required, deletes all notifications when empty
+}
+
+// DeleteNotificationsResponse represents the response from delete_notifications
+type DeleteNotificationsResponse struct {
+	Success bool `json:"success"`
+}
+
+// DeleteNotifications deletes notifications from the wallet
+func (s *WalletService) DeleteNotifications(opts *DeleteNotificationsOptions) (*DeleteNotificationsResponse, *http.Response, error) {
+	request, err := s.NewRequest("delete_notifications", opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r := &DeleteNotificationsResponse{}
+	resp, err := s.Do(request, r)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return r, resp, nil
+}
@@ -0,0 +1,22 @@
+package rpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNFTCalculateRoyaltiesResponse Ensures royalties and errors are both decoded from the top level keys
+func TestNFTCalculateRoyaltiesResponse(t *testing.T) {
+	r := &NFTCalculateRoyaltiesResponse{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"success":true,"nft1":[{"asset":"xch","address":"stai1","amount":25}]}`), r))
+	assert.True(t, r.Success)
+	assert.Len(t, r.Royalties["nft1"], 1)
+
+	r = &NFTCalculateRoyaltiesResponse{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"error":"Royalty asset nft1 is missing","success":false}`), r))
+	assert.False(t, r.Success)
+	assert.Equal(t, "Royalty asset nft1 is missing", r.Error)
+	assert.Empty(t, r.Royalties)
+}
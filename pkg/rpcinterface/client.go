@@ -32,3 +32,11 @@ type Client interface {
 	// Applies to websocket connections
 	AddReconnectHandler(onReconnect ReconnectHandler)
 }
+
+// ConfigReloader is implemented by clients that can reload config.yaml and the SSL files without being recreated
+// This is separate from Client so other implementations of Client don't have to support reloading
+type ConfigReloader interface {
+	// SetConfigReloadInterval sets how often to check config.yaml and the SSL files for changes
+	// An interval of 0 disables reloading
+	SetConfigReloadInterval(interval time.Duration)
+}
@@ -1,5 +1,7 @@
 package rpcinterface
 
+import "github.com/forks-lab/go-stai-libs/pkg/profile"
+
 // ServiceType is a type that refers to a particular service
 type ServiceType uint8
 
@@ -28,3 +30,25 @@ const (
 	// ServiceCrawler crawler service
 	ServiceCrawler
 )
+
+// WebsocketName returns the name the daemon uses for the service in websocket messages, such as stai_full_node
+// Returns an empty string for services that are not reachable over the daemon websocket
+func (s ServiceType) WebsocketName(p *profile.Profile) string {
+	switch s {
+	case ServiceDaemon:
+		return "daemon"
+	case ServiceFullNode:
+		return p.ServiceName("full_node")
+	case ServiceFarmer:
+		return p.ServiceName("farmer")
+	case ServiceHarvester:
+		return p.ServiceName("harvester")
+	case ServiceWallet:
+		return p.ServiceName("wallet")
+	case ServiceTimelord:
+		return p.ServiceName("timelord")
+	case ServiceCrawler:
+		return p.ServiceName("crawler")
+	}
+	return ""
+}
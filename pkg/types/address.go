@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/forks-lab/go-stai-libs/pkg/bech32m"
+)
+
+// AddressNetwork provides the address prefix for a network
+// *config.StaiConfig implements this using the selected network from config.yaml
+type AddressNetwork interface {
+	AddressPrefix() (string, error)
+}
+
+// AddressPrefix is an AddressNetwork for a fixed address prefix, for use without a config
+type AddressPrefix string
+
+// AddressPrefix returns the prefix
+func (p AddressPrefix) AddressPrefix() (string, error) {
+	if p == "" {
+		return "", fmt.Errorf("address prefix is empty")
+	}
+	return string(p), nil
+}
+
+// Address encodes the puzzle hash as an address for the network
+func (p PuzzleHash) Address(network AddressNetwork) (Address, error) {
+	prefix, err := network.AddressPrefix()
+	if err != nil {
+		return "", err
+	}
+
+	address, err := bech32m.EncodePuzzleHash(p, prefix)
+	if err != nil {
+		return "", err
+	}
+
+	return Address(address), nil
+}
+
+// Prefix returns the network prefix of the address, after validating the address
+func (a Address) Prefix() (string, error) {
+	prefix, _, err := bech32m.DecodePuzzleHash(string(a))
+	if err != nil {
+		return "", fmt.Errorf("invalid address %s: %w", a, err)
+	}
+	return prefix, nil
+}
+
+// PuzzleHash decodes the puzzle hash from the address
+// The checksum and length are validated, but the prefix is not. Use PuzzleHashForNetwork to ensure the
+// address belongs to a particular network
+func (a Address) PuzzleHash() (PuzzleHash, error) {
+	_, puzzleHash, err := bech32m.DecodePuzzleHash(string(a))
+	if err != nil {
+		return PuzzleHash{}, fmt.Errorf("invalid address %s: %w", a, err)
+	}
+	return puzzleHash, nil
+}
+
+// PuzzleHashForNetwork decodes the puzzle hash from the address, and returns an error if the
+// address is for a different network
+func (a Address) PuzzleHashForNetwork(network AddressNetwork) (PuzzleHash, error) {
+	err := a.Validate(network)
+	if err != nil {
+		return PuzzleHash{}, err
+	}
+	return a.PuzzleHash()
+}
+
+// Validate returns an error if the address is invalid, or is for a different network
+func (a Address) Validate(network AddressNetwork) error {
+	expected, err := network.AddressPrefix()
+	if err != nil {
+		return err
+	}
+
+	prefix, err := a.Prefix()
+	if err != nil {
+		return err
+	}
+	if prefix != expected {
+		return fmt.Errorf("address %s is for the %s network prefix, expected %s", a, prefix, expected)
+	}
+
+	return nil
+}
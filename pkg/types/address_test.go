@@ -0,0 +1,68 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/config"
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+func TestPuzzleHashAddress(t *testing.T) {
+	puzzleHash, err := types.PuzzleHashFromHexString("0xe8e41b015da5a4df2d505962e301afe9b134840fa984496601b0de6376d0fe18")
+	assert.NoError(t, err)
+
+	address, err := puzzleHash.Address(types.AddressPrefix("xch"))
+	assert.NoError(t, err)
+	assert.Equal(t, types.Address("xch1arjpkq2a5kjd7t2st93wxqd0axcnfpq04xzyjespkr0xxakslcvq3wwwdh"), address)
+
+	decoded, err := address.PuzzleHash()
+	assert.NoError(t, err)
+	assert.Equal(t, puzzleHash, decoded)
+
+	_, err = puzzleHash.Address(types.AddressPrefix(""))
+	assert.Error(t, err)
+}
+
+func TestAddressNetworkFromConfig(t *testing.T) {
+	cfg := &config.StaiConfig{
+		SelectedNetwork: "testnet10",
+		NetworkOverrides: config.NetworkOverrides{
+			Config: map[string]config.NetworkConfig{
+				"mainnet":   {AddressPrefix: "xch"},
+				"testnet10": {AddressPrefix: "txch"},
+			},
+		},
+	}
+
+	puzzleHash, err := types.PuzzleHashFromHexString("000000000000000000000000000000000000000000000000000000000000dead")
+	assert.NoError(t, err)
+
+	address, err := puzzleHash.Address(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Address("txch1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqm6ksh7qddh"), address)
+
+	decoded, err := address.PuzzleHashForNetwork(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, puzzleHash, decoded)
+
+	// A mainnet address must not be accepted on testnet
+	mainnetAddress := types.Address("xch1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqm6ks6e8mvy")
+	assert.Error(t, mainnetAddress.Validate(cfg))
+	_, err = mainnetAddress.PuzzleHashForNetwork(cfg)
+	assert.Error(t, err)
+
+	cfg.SelectedNetwork = "unknown"
+	_, err = puzzleHash.Address(cfg)
+	assert.Error(t, err)
+}
+
+func TestAddressInvalid(t *testing.T) {
+	// Last character changed, so the checksum fails
+	_, err := types.Address("xch1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqm6ks6e8mvz").PuzzleHash()
+	assert.Error(t, err)
+
+	_, err = types.Address("not an address").PuzzleHash()
+	assert.Error(t, err)
+}
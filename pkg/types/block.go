@@ -2,16 +2,16 @@ package types
 
 // BlockRecord a single block record
 type BlockRecord struct {
-	HeaderHash                 string             `json:"header_hash"`
-	PrevHash                   string             `json:"prev_hash"`
+	HeaderHash                 Bytes32            `json:"header_hash"`
+	PrevHash                   Bytes32            `json:"prev_hash"`
 	Height                     uint32             `json:"height"`
 	Weight                     Uint128            `json:"weight"`
 	TotalIters                 Uint128            `json:"total_iters"`
 	SignagePointIndex          uint8              `json:"signage_point_index"`
 	ChallengeVDFOutput         *ClassgroupElement `json:"challenge_vdf_output"`
 	InfusedChallengeVDFOutput  *ClassgroupElement `json:"infused_challenge_vdf_output"`
-	RewardInfusionNewChallenge string             `json:"reward_infusion_new_challenge"`
-	ChallengeBlockInfoHash     string             `json:"challenge_block_info_hash"`
+	RewardInfusionNewChallenge Bytes32            `json:"reward_infusion_new_challenge"`
+	ChallengeBlockInfoHash     Bytes32            `json:"challenge_block_info_hash"`
 	SubSlotIters               uint64             `json:"sub_slot_iters"`
 	PoolPuzzleHash             *PuzzleHash        `json:"pool_puzzle_hash"`
 	FarmerPuzzleHash           *PuzzleHash        `json:"farmer_puzzle_hash"`
@@ -21,15 +21,15 @@ type BlockRecord struct {
 	PrevTransactionBlockHeight uint32             `json:"prev_transaction_block_height"`
 
 	// Transaction Block - Present if is_transaction_block
-	Timestamp                uint64  `json:"timestamp"` // @TODO time.Time ?
-	PrevTransactionBlockHash string  `json:"prev_transaction_block_hash"`
-	Fees                     uint64  `json:"fees"`
-	RewardClaimsIncorporated []*Coin `json:"reward_claims_incorporated"`
+	Timestamp                uint64   `json:"timestamp"` // @TODO time.Time ?
+	PrevTransactionBlockHash *Bytes32 `json:"prev_transaction_block_hash"`
+	Fees                     uint64   `json:"fees"`
+	RewardClaimsIncorporated []*Coin  `json:"reward_claims_incorporated"`
 
 	// Slot - present if this is the first SB in sub slot
-	FinishedChallengeSlotHashes        []string `json:"finished_challenge_slot_hashes"`
-	FinishedInfusedChallengeSlotHashes []string `json:"finished_infused_challenge_slot_hashes"`
-	FinishedRewardSlotHashes           []string `json:"finished_reward_slot_hashes"`
+	FinishedChallengeSlotHashes        []Bytes32 `json:"finished_challenge_slot_hashes"`
+	FinishedInfusedChallengeSlotHashes []Bytes32 `json:"finished_infused_challenge_slot_hashes"`
+	FinishedRewardSlotHashes           []Bytes32 `json:"finished_reward_slot_hashes"`
 
 	// Sub-epoch - present if this is the first SB after sub-epoch
 	SubEpochSummaryIncluded *SubEpochSummary `json:"sub_epoch_summary_included"`
@@ -57,7 +57,7 @@ type RewardChainBlock struct {
 	Height                     uint32        `json:"height"`
 	TotalIters                 Uint128       `json:"total_iters"`
 	SignagePointIndex          uint8         `json:"signage_point_index"`
-	POSSSCCChallengeHash       string        `json:"pos_ss_cc_challenge_hash"`
+	POSSSCCChallengeHash       Bytes32       `json:"pos_ss_cc_challenge_hash"`
 	ProofOfSpace               *ProofOfSpace `json:"proof_of_space"`
 	ChallengeChainSPVDF        *VDFInfo      `json:"challenge_chain_sp_vdf"`
 	ChallengeChainSPSignature  *G2Element    `json:"challenge_chain_sp_signature"`
@@ -103,7 +103,7 @@ const (
 type BlockEvent struct {
 	TransactionBlock              bool               `json:"transaction_block"`
 	KSize                         uint8              `json:"k_size"`
-	HeaderHash                    string             `json:"header_hash"`
+	HeaderHash                    Bytes32            `json:"header_hash"`
 	Height                        uint32             `json:"height"`
 	ValidationTime                float64            `json:"validation_time"`
 	PreValidationTime             float64            `json:"pre_validation_time"`
@@ -114,3 +114,252 @@ type BlockEvent struct {
 	ReceiveBlockResult            ReceiveBlockResult `json:"receive_block_result"`
 	//Timestamp                     type                `json:"timestamp"`
 }
+
+// Stream writes the block record in the streamable format
+// The transaction block fields are only present when PrevTransactionBlockHash is not nil, and the
+// slot lists are only present when they are not nil
+func (b *BlockRecord) Stream(w *StreamWriter) {
+	w.WriteBytes32(b.HeaderHash)
+	w.WriteBytes32(b.PrevHash)
+	w.WriteUint32(b.Height)
+	w.WriteUint128(b.Weight)
+	w.WriteUint128(b.TotalIters)
+	w.WriteUint8(b.SignagePointIndex)
+	if w.required("challenge_vdf_output", b.ChallengeVDFOutput != nil) {
+		b.ChallengeVDFOutput.Stream(w)
+	}
+	w.WriteOptional(b.InfusedChallengeVDFOutput != nil)
+	if b.InfusedChallengeVDFOutput != nil {
+		b.InfusedChallengeVDFOutput.Stream(w)
+	}
+	w.WriteBytes32(b.RewardInfusionNewChallenge)
+	w.WriteBytes32(b.ChallengeBlockInfoHash)
+	w.WriteUint64(b.SubSlotIters)
+	if w.required("pool_puzzle_hash", b.PoolPuzzleHash != nil) {
+		b.PoolPuzzleHash.Stream(w)
+	}
+	if w.required("farmer_puzzle_hash", b.FarmerPuzzleHash != nil) {
+		b.FarmerPuzzleHash.Stream(w)
+	}
+	w.WriteUint64(b.RequiredIters)
+	w.WriteUint8(b.Deficit)
+	w.WriteBool(b.Overflow)
+	w.WriteUint32(b.PrevTransactionBlockHeight)
+
+	isTransactionBlock := b.PrevTransactionBlockHash != nil
+	w.WriteOptional(isTransactionBlock)
+	if isTransactionBlock {
+		w.WriteUint64(b.Timestamp)
+	}
+	streamOptionalBytes32(w, b.PrevTransactionBlockHash)
+	w.WriteOptional(isTransactionBlock)
+	if isTransactionBlock {
+		w.WriteUint64(b.Fees)
+	}
+	w.WriteOptional(b.RewardClaimsIncorporated != nil)
+	if b.RewardClaimsIncorporated != nil {
+		streamCoins(w, b.RewardClaimsIncorporated)
+	}
+
+	for _, hashes := range [][]Bytes32{b.FinishedChallengeSlotHashes, b.FinishedInfusedChallengeSlotHashes, b.FinishedRewardSlotHashes} {
+		w.WriteOptional(hashes != nil)
+		if hashes != nil {
+			streamBytes32List(w, hashes)
+		}
+	}
+
+	w.WriteOptional(b.SubEpochSummaryIncluded != nil)
+	if b.SubEpochSummaryIncluded != nil {
+		b.SubEpochSummaryIncluded.Stream(w)
+	}
+}
+
+// Parse reads the block record from the streamable format
+func (b *BlockRecord) Parse(r *StreamReader) {
+	b.HeaderHash = r.ReadBytes32()
+	b.PrevHash = r.ReadBytes32()
+	b.Height = r.ReadUint32()
+	b.Weight = r.ReadUint128()
+	b.TotalIters = r.ReadUint128()
+	b.SignagePointIndex = r.ReadUint8()
+	b.ChallengeVDFOutput = &ClassgroupElement{}
+	b.ChallengeVDFOutput.Parse(r)
+	b.InfusedChallengeVDFOutput = nil
+	if r.ReadOptional() {
+		b.InfusedChallengeVDFOutput = &ClassgroupElement{}
+		b.InfusedChallengeVDFOutput.Parse(r)
+	}
+	b.RewardInfusionNewChallenge = r.ReadBytes32()
+	b.ChallengeBlockInfoHash = r.ReadBytes32()
+	b.SubSlotIters = r.ReadUint64()
+	b.PoolPuzzleHash = new(PuzzleHash)
+	b.PoolPuzzleHash.Parse(r)
+	b.FarmerPuzzleHash = new(PuzzleHash)
+	b.FarmerPuzzleHash.Parse(r)
+	b.RequiredIters = r.ReadUint64()
+	b.Deficit = r.ReadUint8()
+	b.Overflow = r.ReadBool()
+	b.PrevTransactionBlockHeight = r.ReadUint32()
+
+	b.Timestamp = 0
+	if r.ReadOptional() {
+		b.Timestamp = r.ReadUint64()
+	}
+	b.PrevTransactionBlockHash = parseOptionalBytes32(r)
+	b.Fees = 0
+	if r.ReadOptional() {
+		b.Fees = r.ReadUint64()
+	}
+	b.RewardClaimsIncorporated = nil
+	if r.ReadOptional() {
+		b.RewardClaimsIncorporated = parseCoins(r)
+	}
+
+	for _, hashes := range []*[]Bytes32{&b.FinishedChallengeSlotHashes, &b.FinishedInfusedChallengeSlotHashes, &b.FinishedRewardSlotHashes} {
+		*hashes = nil
+		if r.ReadOptional() {
+			*hashes = parseBytes32List(r)
+		}
+	}
+
+	b.SubEpochSummaryIncluded = nil
+	if r.ReadOptional() {
+		b.SubEpochSummaryIncluded = &SubEpochSummary{}
+		b.SubEpochSummaryIncluded.Parse(r)
+	}
+}
+
+// Stream writes the full block in the streamable format
+func (b *FullBlock) Stream(w *StreamWriter) {
+	w.WriteListLength(len(b.FinishedSubSlots))
+	for _, subSlot := range b.FinishedSubSlots {
+		if !w.required("finished_sub_slot", subSlot != nil) {
+			return
+		}
+		subSlot.Stream(w)
+	}
+	if w.required("reward_chain_block", b.RewardChainBlock != nil) {
+		b.RewardChainBlock.Stream(w)
+	}
+	streamOptionalVDFProof(w, b.ChallengeChainSPProof)
+	if w.required("challenge_chain_ip_proof", b.ChallengeChainIPProof != nil) {
+		b.ChallengeChainIPProof.Stream(w)
+	}
+	streamOptionalVDFProof(w, b.RewardChainSPProof)
+	if w.required("reward_chain_ip_proof", b.RewardChainIPProof != nil) {
+		b.RewardChainIPProof.Stream(w)
+	}
+	streamOptionalVDFProof(w, b.InfusedChallengeChainIPProof)
+	if w.required("foliage", b.Foliage != nil) {
+		b.Foliage.Stream(w)
+	}
+	w.WriteOptional(b.FoliageTransactionBlock != nil)
+	if b.FoliageTransactionBlock != nil {
+		b.FoliageTransactionBlock.Stream(w)
+	}
+	w.WriteOptional(b.TransactionsInfo != nil)
+	if b.TransactionsInfo != nil {
+		b.TransactionsInfo.Stream(w)
+	}
+	w.WriteOptional(b.TransactionsGenerator != nil)
+	if b.TransactionsGenerator != nil {
+		b.TransactionsGenerator.Stream(w)
+	}
+	w.WriteListLength(len(b.TransactionsGeneratorRefList))
+	for _, ref := range b.TransactionsGeneratorRefList {
+		w.WriteUint32(ref)
+	}
+}
+
+// Parse reads the full block from the streamable format
+func (b *FullBlock) Parse(r *StreamReader) {
+	length := r.ReadListLength()
+	b.FinishedSubSlots = make([]*EndOfSubSlotBundle, 0, length)
+	for i := 0; i < length && r.Err() == nil; i++ {
+		subSlot := &EndOfSubSlotBundle{}
+		subSlot.Parse(r)
+		b.FinishedSubSlots = append(b.FinishedSubSlots, subSlot)
+	}
+	b.RewardChainBlock = &RewardChainBlock{}
+	b.RewardChainBlock.Parse(r)
+	b.ChallengeChainSPProof = parseOptionalVDFProof(r)
+	b.ChallengeChainIPProof = &VDFProof{}
+	b.ChallengeChainIPProof.Parse(r)
+	b.RewardChainSPProof = parseOptionalVDFProof(r)
+	b.RewardChainIPProof = &VDFProof{}
+	b.RewardChainIPProof.Parse(r)
+	b.InfusedChallengeChainIPProof = parseOptionalVDFProof(r)
+	b.Foliage = &Foliage{}
+	b.Foliage.Parse(r)
+	b.FoliageTransactionBlock = nil
+	if r.ReadOptional() {
+		b.FoliageTransactionBlock = &FoliageTransactionBlock{}
+		b.FoliageTransactionBlock.Parse(r)
+	}
+	b.TransactionsInfo = nil
+	if r.ReadOptional() {
+		b.TransactionsInfo = &TransactionsInfo{}
+		b.TransactionsInfo.Parse(r)
+	}
+	b.TransactionsGenerator = nil
+	if r.ReadOptional() {
+		b.TransactionsGenerator = new(SerializedProgram)
+		b.TransactionsGenerator.Parse(r)
+	}
+	length = r.ReadListLength()
+	b.TransactionsGeneratorRefList = make([]uint32, 0, length)
+	for i := 0; i < length && r.Err() == nil; i++ {
+		b.TransactionsGeneratorRefList = append(b.TransactionsGeneratorRefList, r.ReadUint32())
+	}
+}
+
+// Stream writes the reward chain block in the streamable format
+func (b *RewardChainBlock) Stream(w *StreamWriter) {
+	w.WriteUint128(b.Weight)
+	w.WriteUint32(b.Height)
+	w.WriteUint128(b.TotalIters)
+	w.WriteUint8(b.SignagePointIndex)
+	w.WriteBytes32(b.POSSSCCChallengeHash)
+	if w.required("proof_of_space", b.ProofOfSpace != nil) {
+		b.ProofOfSpace.Stream(w)
+	}
+	streamOptionalVDFInfo(w, b.ChallengeChainSPVDF)
+	if w.required("challenge_chain_sp_signature", b.ChallengeChainSPSignature != nil) {
+		b.ChallengeChainSPSignature.Stream(w)
+	}
+	if w.required("challenge_chain_ip_vdf", b.ChallengeChainIPVDF != nil) {
+		b.ChallengeChainIPVDF.Stream(w)
+	}
+	streamOptionalVDFInfo(w, b.RewardChainSPVDF)
+	if w.required("reward_chain_sp_signature", b.RewardChainSPSignature != nil) {
+		b.RewardChainSPSignature.Stream(w)
+	}
+	if w.required("reward_chain_ip_vdf", b.RewardChainIPVDF != nil) {
+		b.RewardChainIPVDF.Stream(w)
+	}
+	streamOptionalVDFInfo(w, b.InfusedChallengeChainIPVDF)
+	w.WriteBool(b.IsTransactionBlock)
+}
+
+// Parse reads the reward chain block from the streamable format
+func (b *RewardChainBlock) Parse(r *StreamReader) {
+	b.Weight = r.ReadUint128()
+	b.Height = r.ReadUint32()
+	b.TotalIters = r.ReadUint128()
+	b.SignagePointIndex = r.ReadUint8()
+	b.POSSSCCChallengeHash = r.ReadBytes32()
+	b.ProofOfSpace = &ProofOfSpace{}
+	b.ProofOfSpace.Parse(r)
+	b.ChallengeChainSPVDF = parseOptionalVDFInfo(r)
+	b.ChallengeChainSPSignature = new(G2Element)
+	b.ChallengeChainSPSignature.Parse(r)
+	b.ChallengeChainIPVDF = &VDFInfo{}
+	b.ChallengeChainIPVDF.Parse(r)
+	b.RewardChainSPVDF = parseOptionalVDFInfo(r)
+	b.RewardChainSPSignature = new(G2Element)
+	b.RewardChainSPSignature.Parse(r)
+	b.RewardChainIPVDF = &VDFInfo{}
+	b.RewardChainIPVDF.Parse(r)
+	b.InfusedChallengeChainIPVDF = parseOptionalVDFInfo(r)
+	b.IsTransactionBlock = r.ReadBool()
+}
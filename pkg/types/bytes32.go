@@ -21,3 +21,63 @@ func BytesToBytes32(bytes []byte) ([32]byte, error) {
 	copy(fixedLen[:], bytes)
 	return fixedLen, nil
 }
+
+// Bytes32 is a 32 byte value, such as a hash or coin ID
+// It is marshaled to JSON and text as 0x prefixed hex, the same format the RPCs use. Both 0x prefixed and bare
+// hex are accepted when unmarshaling. Bytes32 values can be compared directly with ==
+type Bytes32 [32]byte
+
+// Bytes32FromHexString parses 0x prefixed or bare hex, which must be exactly 32 bytes
+func Bytes32FromHexString(hexStr string) (Bytes32, error) {
+	var b32 Bytes32
+	b, err := decodeHexString(hexStr)
+	if err != nil {
+		return b32, err
+	}
+	return Bytes32FromBytes(b)
+}
+
+// Bytes32FromBytes returns a Bytes32 from a slice, which must be exactly 32 bytes
+func Bytes32FromBytes(b []byte) (Bytes32, error) {
+	var b32 Bytes32
+	if len(b) != len(b32) {
+		return b32, fmt.Errorf("expected 32 bytes, got %d", len(b))
+	}
+	copy(b32[:], b)
+	return b32, nil
+}
+
+// Bytes returns the value as a byte slice
+func (b Bytes32) Bytes() []byte {
+	return b[:]
+}
+
+// String returns the value as 0x prefixed hex
+func (b Bytes32) String() string {
+	return encodeHexString(b[:])
+}
+
+// IsZero returns true if every byte is zero
+func (b Bytes32) IsZero() bool {
+	return b == Bytes32{}
+}
+
+// MarshalText marshals the value to 0x prefixed hex
+func (b Bytes32) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText unmarshals 0x prefixed or bare hex
+// An empty string is the zero value, since some endpoints return empty strings in place of missing values
+func (b *Bytes32) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*b = Bytes32{}
+		return nil
+	}
+	parsed, err := Bytes32FromHexString(string(text))
+	if err != nil {
+		return err
+	}
+	*b = parsed
+	return nil
+}
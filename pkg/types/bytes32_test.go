@@ -1,6 +1,8 @@
 package types_test
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,3 +26,60 @@ func TestBytesToBytes32(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, [32]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}, okBytes32)
 }
+
+func TestBytes32JSON(t *testing.T) {
+	hexStr := "0x000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	expected := types.Bytes32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}
+
+	var prefixed types.Bytes32
+	assert.NoError(t, json.Unmarshal([]byte(`"`+hexStr+`"`), &prefixed))
+	assert.Equal(t, expected, prefixed)
+
+	var bare types.Bytes32
+	assert.NoError(t, json.Unmarshal([]byte(`"`+hexStr[2:]+`"`), &bare))
+	assert.Equal(t, expected, bare)
+	assert.True(t, prefixed == bare)
+
+	marshaled, err := json.Marshal(prefixed)
+	assert.NoError(t, err)
+	assert.Equal(t, `"`+hexStr+`"`, string(marshaled))
+	assert.Equal(t, hexStr, prefixed.String())
+
+	var empty types.Bytes32
+	assert.NoError(t, json.Unmarshal([]byte(`""`), &empty))
+	assert.True(t, empty.IsZero())
+
+	var invalid types.Bytes32
+	assert.Error(t, json.Unmarshal([]byte(`"0x0102"`), &invalid))
+	assert.Error(t, json.Unmarshal([]byte(`"0xzz"`), &invalid))
+}
+
+func TestPuzzleHashJSON(t *testing.T) {
+	type wrapper struct {
+		PuzzleHash *types.PuzzleHash `json:"puzzle_hash"`
+	}
+
+	var w wrapper
+	assert.NoError(t, json.Unmarshal([]byte(`{"puzzle_hash":"0x0000000000000000000000000000000000000000000000000000000000000001"}`), &w))
+	assert.NotNil(t, w.PuzzleHash)
+	assert.Equal(t, byte(1), w.PuzzleHash[31])
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000001", w.PuzzleHash.String())
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"puzzle_hash":null}`), &w))
+	assert.Nil(t, w.PuzzleHash)
+}
+
+func TestNewCoinFromHexStrings(t *testing.T) {
+	coin, err := types.NewCoinFromHexStrings("0x"+strings.Repeat("11", 32), strings.Repeat("22", 32), 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, b32(0x11), coin.ParentCoinInfo)
+	assert.Equal(t, types.PuzzleHash(b32(0x22)), coin.PuzzleHash)
+	assert.Equal(t, uint64(1000), coin.Amount.Uint64())
+	assert.Equal(t, "0x"+strings.Repeat("11", 32), coin.ParentCoinInfoString())
+	assert.Equal(t, "0x"+strings.Repeat("22", 32), coin.PuzzleHashString())
+
+	_, err = types.NewCoinFromHexStrings("0x11", strings.Repeat("22", 32), 1000)
+	assert.Error(t, err)
+	_, err = types.NewCoinFromHexStrings(strings.Repeat("11", 32), "", 1000)
+	assert.Error(t, err)
+}
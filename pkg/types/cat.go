@@ -0,0 +1,16 @@
+package types
+
+// CAT is a known CAT, as returned by get_cat_list
+type CAT struct {
+	AssetID string `json:"asset_id"`
+	Name    string `json:"name"`
+	Symbol  string `json:"symbol"`
+}
+
+// StrayCAT is a CAT the wallet has received, but does not yet have a wallet for
+type StrayCAT struct {
+	AssetID          string     `json:"asset_id"`
+	Name             string     `json:"name"`
+	FirstSeenHeight  uint32     `json:"first_seen_height"`
+	SenderPuzzleHash PuzzleHash `json:"sender_puzzle_hash"`
+}
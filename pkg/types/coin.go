@@ -1,10 +1,14 @@
 package types
 
+import (
+	"fmt"
+)
+
 // Coin is a coin
 type Coin struct {
-	Amount         Uint128 `json:"amount"`
-	ParentCoinInfo string  `json:"parent_coin_info"`
-	PuzzleHash     string  `json:"puzzle_hash"`
+	Amount         Uint128    `json:"amount"`
+	ParentCoinInfo Bytes32    `json:"parent_coin_info"`
+	PuzzleHash     PuzzleHash `json:"puzzle_hash"`
 }
 
 // CoinSolution solution to a coin
@@ -20,3 +24,67 @@ type CoinAddedEvent struct {
 	State    string `json:"state"`
 	WalletID uint32 `json:"wallet_id"`
 }
+
+// Stream writes the coin in the streamable format
+func (c *Coin) Stream(w *StreamWriter) {
+	w.WriteBytes32(c.ParentCoinInfo)
+	w.WriteBytes32(Bytes32(c.PuzzleHash))
+	if !c.Amount.FitsInUint64() {
+		w.fail(fmt.Errorf("coin amount %s does not fit in uint64", c.Amount.String()))
+		return
+	}
+	w.WriteUint64(c.Amount.Uint64())
+}
+
+// Parse reads the coin from the streamable format
+func (c *Coin) Parse(r *StreamReader) {
+	c.ParentCoinInfo = r.ReadBytes32()
+	c.PuzzleHash = PuzzleHash(r.ReadBytes32())
+	c.Amount = Uint128From64(r.ReadUint64())
+}
+
+// Stream writes the coin solution in the streamable format
+func (c *CoinSolution) Stream(w *StreamWriter) {
+	if w.required("coin", c.Coin != nil) {
+		c.Coin.Stream(w)
+	}
+	if w.required("puzzle_reveal", c.PuzzleReveal != nil) {
+		c.PuzzleReveal.Stream(w)
+	}
+	if w.required("solution", c.Solution != nil) {
+		c.Solution.Stream(w)
+	}
+}
+
+// Parse reads the coin solution from the streamable format
+func (c *CoinSolution) Parse(r *StreamReader) {
+	c.Coin = &Coin{}
+	c.Coin.Parse(r)
+	c.PuzzleReveal = new(SerializedProgram)
+	c.PuzzleReveal.Parse(r)
+	c.Solution = new(SerializedProgram)
+	c.Solution.Parse(r)
+}
+
+// streamCoins writes a list of coins
+func streamCoins(w *StreamWriter, coins []*Coin) {
+	w.WriteListLength(len(coins))
+	for _, coin := range coins {
+		if !w.required("coin", coin != nil) {
+			return
+		}
+		coin.Stream(w)
+	}
+}
+
+// parseCoins reads a list of coins
+func parseCoins(r *StreamReader) []*Coin {
+	length := r.ReadListLength()
+	coins := make([]*Coin, 0, length)
+	for i := 0; i < length && r.Err() == nil; i++ {
+		coin := &Coin{}
+		coin.Parse(r)
+		coins = append(coins, coin)
+	}
+	return coins
+}
@@ -0,0 +1,18 @@
+package types
+
+import "encoding/json"
+
+// DIDInfo information about a DID, as returned by did_get_info
+type DIDInfo struct {
+	DIDID            string            `json:"did_id"`
+	LatestCoin       string            `json:"latest_coin"`
+	P2Address        *Address          `json:"p2_address"`
+	PublicKey        string            `json:"public_key"`
+	RecoveryListHash string            `json:"recovery_list_hash"`
+	NumVerification  uint64            `json:"num_verification"`
+	Metadata         map[string]string `json:"metadata"`
+	LauncherID       Bytes32           `json:"launcher_id"`
+	FullPuzzle       SerializedProgram `json:"full_puzzle"`
+	Solution         json.RawMessage   `json:"solution"` // Solution is returned as the program's python representation
+	Hints            []string          `json:"hints"`
+}
@@ -2,39 +2,143 @@ package types
 
 // FoliageBlockData FoliageBlockData
 type FoliageBlockData struct {
-	UnfinishedRewardBlockHash string      `json:"unfinished_reward_block_hash"`
+	UnfinishedRewardBlockHash Bytes32     `json:"unfinished_reward_block_hash"`
 	PoolTarget                *PoolTarget `json:"pool_target"`
 	PoolSignature             *G2Element  `json:"pool_signature"`
-	FarmerRewardPuzzleHash    string      `json:"farmer_reward_puzzle_hash"`
-	ExtensionData             string      `json:"extension_data"`
+	FarmerRewardPuzzleHash    PuzzleHash  `json:"farmer_reward_puzzle_hash"`
+	ExtensionData             Bytes32     `json:"extension_data"`
 }
 
 // Foliage Foliage
 type Foliage struct {
-	PrevBlockHash                    string            `json:"prev_block_hash"`
-	RewardBlockHash                  string            `json:"reward_block_hash"`
+	PrevBlockHash                    Bytes32           `json:"prev_block_hash"`
+	RewardBlockHash                  Bytes32           `json:"reward_block_hash"`
 	FoliageBlockData                 *FoliageBlockData `json:"foliage_block_data"`
 	FoliageBlockDataSignature        *G2Element        `json:"foliage_block_data_signature"`
-	FoliageTransactionBlockHash      string            `json:"foliage_transaction_block_hash"`
+	FoliageTransactionBlockHash      *Bytes32          `json:"foliage_transaction_block_hash"`
 	FoliageTransactionBlockSignature *G2Element        `json:"foliage_transaction_block_signature"`
 }
 
 // FoliageTransactionBlock foliage transaction block
 type FoliageTransactionBlock struct {
-	PrevTransactionBlockHash string `json:"prev_transaction_block_hash"`
-	Timestamp                uint64 `json:"timestamp"` // @TODO time.Time?
-	FilterHash               string `json:"filter_hash"`
-	AdditionsRoot            string `json:"additions_root"`
-	RemovalsRoot             string `json:"removals_root"`
-	TransactionsInfoHash     string `json:"transactions_info_hash"`
+	PrevTransactionBlockHash Bytes32 `json:"prev_transaction_block_hash"`
+	Timestamp                uint64  `json:"timestamp"` // @TODO time.Time?
+	FilterHash               Bytes32 `json:"filter_hash"`
+	AdditionsRoot            Bytes32 `json:"additions_root"`
+	RemovalsRoot             Bytes32 `json:"removals_root"`
+	TransactionsInfoHash     Bytes32 `json:"transactions_info_hash"`
 }
 
 // TransactionsInfo transactions info
 type TransactionsInfo struct {
-	GeneratorRoot            string     `json:"generator_root"`
-	GeneratorRefsRoot        string     `json:"generator_refs_root"`
+	GeneratorRoot            Bytes32    `json:"generator_root"`
+	GeneratorRefsRoot        Bytes32    `json:"generator_refs_root"`
 	AggregatedSignature      *G2Element `json:"aggregated_signature"`
 	Fees                     uint64     `json:"fees"`
 	Cost                     uint64     `json:"cost"`
 	RewardClaimsIncorporated []*Coin    `json:"reward_claims_incorporated"`
 }
+
+// Stream writes the foliage block data in the streamable format
+func (f *FoliageBlockData) Stream(w *StreamWriter) {
+	w.WriteBytes32(f.UnfinishedRewardBlockHash)
+	if w.required("pool_target", f.PoolTarget != nil) {
+		f.PoolTarget.Stream(w)
+	}
+	w.WriteOptional(f.PoolSignature != nil)
+	if f.PoolSignature != nil {
+		f.PoolSignature.Stream(w)
+	}
+	w.WriteBytes32(Bytes32(f.FarmerRewardPuzzleHash))
+	w.WriteBytes32(f.ExtensionData)
+}
+
+// Parse reads the foliage block data from the streamable format
+func (f *FoliageBlockData) Parse(r *StreamReader) {
+	f.UnfinishedRewardBlockHash = r.ReadBytes32()
+	f.PoolTarget = &PoolTarget{}
+	f.PoolTarget.Parse(r)
+	f.PoolSignature = nil
+	if r.ReadOptional() {
+		f.PoolSignature = new(G2Element)
+		f.PoolSignature.Parse(r)
+	}
+	f.FarmerRewardPuzzleHash = PuzzleHash(r.ReadBytes32())
+	f.ExtensionData = r.ReadBytes32()
+}
+
+// Stream writes the foliage in the streamable format
+func (f *Foliage) Stream(w *StreamWriter) {
+	w.WriteBytes32(f.PrevBlockHash)
+	w.WriteBytes32(f.RewardBlockHash)
+	if w.required("foliage_block_data", f.FoliageBlockData != nil) {
+		f.FoliageBlockData.Stream(w)
+	}
+	if w.required("foliage_block_data_signature", f.FoliageBlockDataSignature != nil) {
+		f.FoliageBlockDataSignature.Stream(w)
+	}
+	streamOptionalBytes32(w, f.FoliageTransactionBlockHash)
+	w.WriteOptional(f.FoliageTransactionBlockSignature != nil)
+	if f.FoliageTransactionBlockSignature != nil {
+		f.FoliageTransactionBlockSignature.Stream(w)
+	}
+}
+
+// Parse reads the foliage from the streamable format
+func (f *Foliage) Parse(r *StreamReader) {
+	f.PrevBlockHash = r.ReadBytes32()
+	f.RewardBlockHash = r.ReadBytes32()
+	f.FoliageBlockData = &FoliageBlockData{}
+	f.FoliageBlockData.Parse(r)
+	f.FoliageBlockDataSignature = new(G2Element)
+	f.FoliageBlockDataSignature.Parse(r)
+	f.FoliageTransactionBlockHash = parseOptionalBytes32(r)
+	f.FoliageTransactionBlockSignature = nil
+	if r.ReadOptional() {
+		f.FoliageTransactionBlockSignature = new(G2Element)
+		f.FoliageTransactionBlockSignature.Parse(r)
+	}
+}
+
+// Stream writes the foliage transaction block in the streamable format
+func (f *FoliageTransactionBlock) Stream(w *StreamWriter) {
+	w.WriteBytes32(f.PrevTransactionBlockHash)
+	w.WriteUint64(f.Timestamp)
+	w.WriteBytes32(f.FilterHash)
+	w.WriteBytes32(f.AdditionsRoot)
+	w.WriteBytes32(f.RemovalsRoot)
+	w.WriteBytes32(f.TransactionsInfoHash)
+}
+
+// Parse reads the foliage transaction block from the streamable format
+func (f *FoliageTransactionBlock) Parse(r *StreamReader) {
+	f.PrevTransactionBlockHash = r.ReadBytes32()
+	f.Timestamp = r.ReadUint64()
+	f.FilterHash = r.ReadBytes32()
+	f.AdditionsRoot = r.ReadBytes32()
+	f.RemovalsRoot = r.ReadBytes32()
+	f.TransactionsInfoHash = r.ReadBytes32()
+}
+
+// Stream writes the transactions info in the streamable format
+func (t *TransactionsInfo) Stream(w *StreamWriter) {
+	w.WriteBytes32(t.GeneratorRoot)
+	w.WriteBytes32(t.GeneratorRefsRoot)
+	if w.required("aggregated_signature", t.AggregatedSignature != nil) {
+		t.AggregatedSignature.Stream(w)
+	}
+	w.WriteUint64(t.Fees)
+	w.WriteUint64(t.Cost)
+	streamCoins(w, t.RewardClaimsIncorporated)
+}
+
+// Parse reads the transactions info from the streamable format
+func (t *TransactionsInfo) Parse(r *StreamReader) {
+	t.GeneratorRoot = r.ReadBytes32()
+	t.GeneratorRefsRoot = r.ReadBytes32()
+	t.AggregatedSignature = new(G2Element)
+	t.AggregatedSignature.Parse(r)
+	t.Fees = r.ReadUint64()
+	t.Cost = r.ReadUint64()
+	t.RewardClaimsIncorporated = parseCoins(r)
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// hashStreamable returns the sha256 hash of the streamable encoding of the value
+func hashStreamable(value Streamable) (Bytes32, error) {
+	data, err := ToBytes(value)
+	if err != nil {
+		return Bytes32{}, err
+	}
+	return sha256.Sum256(data), nil
+}
+
+// ID returns the coin ID (also called the coin name), which is the sha256 hash of the parent coin info,
+// the puzzle hash, and the amount encoded as a minimal CLVM integer
+func (c *Coin) ID() (Bytes32, error) {
+	if !c.Amount.FitsInUint64() {
+		return Bytes32{}, fmt.Errorf("coin amount %s does not fit in uint64", c.Amount.String())
+	}
+
+	h := sha256.New()
+	h.Write(c.ParentCoinInfo[:])
+	h.Write(c.PuzzleHash[:])
+	h.Write(clvmUint64Bytes(c.Amount.Uint64()))
+
+	var id Bytes32
+	copy(id[:], h.Sum(nil))
+	return id, nil
+}
+
+// clvmUint64Bytes encodes the value as the shortest big-endian two's complement bytes, the same way CLVM
+// encodes integers. Zero is encoded as no bytes
+func clvmUint64Bytes(v uint64) []byte {
+	var b []byte
+	for ; v > 0; v >>= 8 {
+		b = append([]byte{byte(v)}, b...)
+	}
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		// Prevent the value from being interpreted as negative
+		b = append([]byte{0}, b...)
+	}
+	return b
+}
+
+// Name returns the name of the spend bundle, which is the sha256 hash of the streamable encoding
+func (s *SpendBundle) Name() (Bytes32, error) {
+	return hashStreamable(s)
+}
+
+// Hash returns the hash of the foliage, which is the header hash of the block
+func (f *Foliage) Hash() (Bytes32, error) {
+	return hashStreamable(f)
+}
+
+// Hash returns the hash of the foliage block data
+func (f *FoliageBlockData) Hash() (Bytes32, error) {
+	return hashStreamable(f)
+}
+
+// Hash returns the hash of the foliage transaction block
+// This should match Foliage.FoliageTransactionBlockHash
+func (f *FoliageTransactionBlock) Hash() (Bytes32, error) {
+	return hashStreamable(f)
+}
+
+// Hash returns the hash of the transactions info
+// This should match FoliageTransactionBlock.TransactionsInfoHash
+func (t *TransactionsInfo) Hash() (Bytes32, error) {
+	return hashStreamable(t)
+}
+
+// Hash returns the hash of the reward chain block
+// This should match Foliage.RewardBlockHash
+func (b *RewardChainBlock) Hash() (Bytes32, error) {
+	return hashStreamable(b)
+}
+
+// HeaderHash computes the header hash of the block from its foliage
+// This should match BlockRecord.HeaderHash for the same block
+func (b *FullBlock) HeaderHash() (Bytes32, error) {
+	if b.Foliage == nil {
+		return Bytes32{}, fmt.Errorf("required field foliage is missing")
+	}
+	return b.Foliage.Hash()
+}
@@ -0,0 +1,148 @@
+package types_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// Expected hashes in this file were computed independently from the STAI/Chia definitions with python's hashlib
+// The node fixtures in testdata are responses captured from a full node, see testdata/readme.md
+
+// loadNodeFixture decodes a response captured from a full node, skipping the test if it has not been captured
+func loadNodeFixture(t *testing.T, name string, v interface{}) {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if os.IsNotExist(err) {
+		t.Skipf("node fixture testdata/%s has not been captured, see testdata/readme.md", name)
+	}
+	if assert.NoError(t, err) {
+		assert.NoError(t, json.Unmarshal(data, v))
+	}
+}
+
+func TestCoinID(t *testing.T) {
+	tests := []struct {
+		amount uint64
+		id     string
+	}{
+		{0, "0x5189c77d29fe5d546a045ec46986852785fea5c13ac7da9c115ff5fb6edf817c"},
+		{1, "0x4a9fde315fe45879b6d5e2ab6ae6582c9909b96c1197602efc6679eb17157d99"},
+		{127, "0xc4e517106319b0d8f99286bb56eab269e4c00ac47038a13f17f18ace5bf0828a"},
+		{128, "0xd254c74c04ffcffe39ebc936fddba7a203723dcc083ef3004fc3f70477d6a7d4"},
+		{255, "0x9d098f8ad1d7e5c701ae7ad154079dda808342658bae32166985e3aaeb9be2a2"},
+		{1750000000000, "0x170609097d986ba2e45cd6da77eb64d0ae5661a3505327d896ad0b57e69888a5"},
+		{18446744073709551615, "0x3ef8011d9bbe0a3e37b7485c6e316a9cd3aac32f213beac9b766aa71b6cb6c0d"},
+	}
+
+	for _, test := range tests {
+		coin := &types.Coin{
+			ParentCoinInfo: b32(0x11),
+			PuzzleHash:     types.PuzzleHash(b32(0x22)),
+			Amount:         types.Uint128From64(test.amount),
+		}
+		id, err := coin.ID()
+		assert.NoError(t, err)
+		assert.Equal(t, test.id, id.String(), "amount %d", test.amount)
+	}
+
+	_, err := (&types.Coin{Amount: types.NewUint128(0, 1)}).ID()
+	assert.Error(t, err)
+}
+
+func TestSpendBundleName(t *testing.T) {
+	puzzle := types.SerializedProgram("0xff01ff8300aabb80")
+	solution := types.SerializedProgram("0x80")
+	bundle := &types.SpendBundle{
+		CoinSolutions: []*types.CoinSolution{
+			{
+				Coin:         &types.Coin{ParentCoinInfo: b32(0x01), PuzzleHash: types.PuzzleHash(b32(0x02)), Amount: types.Uint128From64(1)},
+				PuzzleReveal: &puzzle,
+				Solution:     &solution,
+			},
+		},
+		AggregatedSignature: types.G2Element(hexBytes(0xc0, 96)),
+	}
+
+	name, err := bundle.Name()
+	assert.NoError(t, err)
+	assert.Equal(t, "0x14b955f03c46c187e74374f1987bb53db03c32e862bbe1824c0f4f4b69f1bbdc", name.String())
+}
+
+func TestFoliageHash(t *testing.T) {
+	signature := types.G2Element(hexBytes(0xc0, 96))
+	poolPuzzleHash := types.PuzzleHash(b32(0x03))
+	foliage := &types.Foliage{
+		PrevBlockHash:   b32(0x0a),
+		RewardBlockHash: b32(0x0b),
+		FoliageBlockData: &types.FoliageBlockData{
+			UnfinishedRewardBlockHash: b32(0x0c),
+			PoolTarget:                &types.PoolTarget{PuzzleHash: &poolPuzzleHash, MaxHeight: 0},
+			PoolSignature:             &signature,
+			FarmerRewardPuzzleHash:    types.PuzzleHash(b32(0x0d)),
+			ExtensionData:             b32(0x00),
+		},
+		FoliageBlockDataSignature:   &signature,
+		FoliageTransactionBlockHash: b32Ptr(0x0e),
+	}
+
+	hash, err := foliage.FoliageBlockData.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "0x86ac1ebc17d8804a8bae4b471d949d1c1ab4cdd9b73b3ddc1ddcc2f41417617a", hash.String())
+
+	hash, err = foliage.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, "0xa89c679f80949320af07d3c8fece6cc4fe9c8de68b8f1a17c8a0fbcfd4cb0e40", hash.String())
+
+	headerHash, err := (&types.FullBlock{Foliage: foliage}).HeaderHash()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, headerHash)
+}
+
+// TestNodeHeaderHash Ensures header hashes computed from the foliage match the header hashes returned by the node
+func TestNodeHeaderHash(t *testing.T) {
+	var response struct {
+		Blocks []json.RawMessage `json:"blocks"`
+	}
+	loadNodeFixture(t, "get_blocks.json", &response)
+
+	transactionBlocks := 0
+	for _, data := range response.Blocks {
+		block := &types.FullBlock{}
+		assert.NoError(t, json.Unmarshal(data, block))
+		if block.FoliageTransactionBlock != nil {
+			transactionBlocks++
+		}
+		var expected struct {
+			HeaderHash types.Bytes32 `json:"header_hash"`
+		}
+		assert.NoError(t, json.Unmarshal(data, &expected))
+
+		hash, err := block.HeaderHash()
+		assert.NoError(t, err)
+		assert.Equal(t, expected.HeaderHash, hash, "height %d", block.RewardChainBlock.Height)
+	}
+
+	// Only transaction blocks have a foliage transaction block hash and signature, so both kinds must be checked
+	assert.NotZero(t, transactionBlocks, "get_blocks.json has no transaction blocks")
+	assert.NotZero(t, len(response.Blocks)-transactionBlocks, "get_blocks.json has no non-transaction blocks")
+}
+
+// TestNodeCoinID Ensures the coin ID matches the name the coin record was requested with
+func TestNodeCoinID(t *testing.T) {
+	var response struct {
+		Name       types.Bytes32 `json:"name"`
+		CoinRecord struct {
+			Coin *types.Coin `json:"coin"`
+		} `json:"coin_record"`
+	}
+	loadNodeFixture(t, "get_coin_record_by_name.json", &response)
+
+	id, err := response.CoinRecord.Coin.ID()
+	assert.NoError(t, err)
+	assert.Equal(t, response.Name, id)
+}
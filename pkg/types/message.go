@@ -0,0 +1,32 @@
+package types
+
+// SigningMode the scheme used to sign a message
+// These values match values in STAI blockchain. Must not be arbitrarily changed
+type SigningMode string
+
+const (
+	// SigningModeCHIP0002 message is signed according to CHIP-0002
+	SigningModeCHIP0002 SigningMode = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG:CHIP-0002_"
+
+	// SigningModeBLSMessageAugmentationUTF8Input message is signed as a utf8 string
+	SigningModeBLSMessageAugmentationUTF8Input SigningMode = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG:utf8input_"
+
+	// SigningModeBLSMessageAugmentationHexInput message is signed as hex encoded bytes
+	SigningModeBLSMessageAugmentationHexInput SigningMode = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG:hexinput_"
+)
+
+// SignedMessage a message signed by the wallet
+type SignedMessage struct {
+	PubKey       G1Element   `json:"pubkey"`
+	Signature    G2Element   `json:"signature"`
+	SigningMode  SigningMode `json:"signing_mode"`
+	LatestCoinID string      `json:"latest_coin_id,omitempty"` // Only present when signing with a DID or NFT
+}
+
+// Notification an on chain notification received by the wallet
+type Notification struct {
+	ID      string `json:"id"`
+	Message string `json:"message"` // hex encoded
+	Amount  uint64 `json:"amount"`
+	Height  uint32 `json:"height"`
+}
@@ -0,0 +1,80 @@
+package types
+
+// Hashes used to be hex strings. Changing them to Bytes32 and PuzzleHash is a breaking change, and code that set or
+// compared the string fields must be updated. The constructors and accessors in this file make that update easier
+
+// NewCoinFromHexStrings returns a coin from a hex encoded parent coin info and puzzle hash, with or without the 0x prefix
+func NewCoinFromHexStrings(parentCoinInfo, puzzleHash string, amount uint64) (*Coin, error) {
+	parent, err := Bytes32FromHexString(parentCoinInfo)
+	if err != nil {
+		return nil, err
+	}
+	ph, err := PuzzleHashFromHexString(puzzleHash)
+	if err != nil {
+		return nil, err
+	}
+	return &Coin{ParentCoinInfo: parent, PuzzleHash: ph, Amount: Uint128From64(amount)}, nil
+}
+
+// ParentCoinInfoString returns the parent coin info as 0x prefixed hex
+//
+// Deprecated: Use ParentCoinInfo.String()
+func (c *Coin) ParentCoinInfoString() string {
+	return c.ParentCoinInfo.String()
+}
+
+// PuzzleHashString returns the puzzle hash as 0x prefixed hex
+//
+// Deprecated: Use PuzzleHash.String()
+func (c *Coin) PuzzleHashString() string {
+	return c.PuzzleHash.String()
+}
+
+// HeaderHashString returns the header hash as 0x prefixed hex
+//
+// Deprecated: Use HeaderHash.String()
+func (b *BlockRecord) HeaderHashString() string {
+	return b.HeaderHash.String()
+}
+
+// PrevHashString returns the previous block's header hash as 0x prefixed hex
+//
+// Deprecated: Use PrevHash.String()
+func (b *BlockRecord) PrevHashString() string {
+	return b.PrevHash.String()
+}
+
+// HeaderHashString returns the header hash as 0x prefixed hex
+//
+// Deprecated: Use HeaderHash.String()
+func (b *BlockEvent) HeaderHashString() string {
+	return b.HeaderHash.String()
+}
+
+// LauncherIDString returns the launcher ID as 0x prefixed hex
+//
+// Deprecated: Use LauncherID.String()
+func (n *NFT) LauncherIDString() string {
+	return n.LauncherID.String()
+}
+
+// NftCoinIDString returns the NFT coin ID as 0x prefixed hex
+//
+// Deprecated: Use NftCoinID.String()
+func (n *NFT) NftCoinIDString() string {
+	return n.NftCoinID.String()
+}
+
+// LauncherIDString returns the launcher ID as 0x prefixed hex
+//
+// Deprecated: Use LauncherID.String()
+func (d *DIDInfo) LauncherIDString() string {
+	return d.LauncherID.String()
+}
+
+// LauncherIDString returns the launcher ID as 0x prefixed hex
+//
+// Deprecated: Use LauncherID.String()
+func (p *PoolWalletInfo) LauncherIDString() string {
+	return p.LauncherID.String()
+}
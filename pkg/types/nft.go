@@ -2,23 +2,62 @@ package types
 
 // NFT is an NFT
 type NFT struct {
-	ChainInfo          string   `json:"chain_info"`
-	DataHash           string   `json:"data_hash"`
-	DataUris           []string `json:"data_uris"`
-	LauncherID         string   `json:"launcher_id"`
-	LauncherPuzhash    string   `json:"launcher_puzhash"`
-	LicenseHash        string   `json:"license_hash"`
-	LicenseURIs        []string `json:"license_uris"`
-	MetadataHash       string   `json:"metadata_hash"`
-	MetadataURIs       []string `json:"metadata_uris"`
-	MintHeight         uint32   `json:"mint_height"`
-	NftCoinID          string   `json:"nft_coin_id"`
-	OwnerDid           string   `json:"owner_did"`
-	PendingTransaction bool     `json:"pending_transaction"`
-	RoyaltyPercentage  uint32   `json:"royalty_percentage"`
-	RoyaltyPuzzleHash  string   `json:"royalty_puzzle_hash"`
-	EditionNumber      uint32   `json:"edition_number"`
-	EditionCount       uint32   `json:"edition_count"`
-	SupportsDid        bool     `json:"supports_did"`
-	UpdaterPuzhash     string   `json:"updater_puzhash"`
+	ChainInfo          string      `json:"chain_info"`
+	DataHash           string      `json:"data_hash"`
+	DataUris           []string    `json:"data_uris"`
+	LauncherID         Bytes32     `json:"launcher_id"`
+	LauncherPuzhash    PuzzleHash  `json:"launcher_puzhash"`
+	LicenseHash        string      `json:"license_hash"`
+	LicenseURIs        []string    `json:"license_uris"`
+	MetadataHash       string      `json:"metadata_hash"`
+	MetadataURIs       []string    `json:"metadata_uris"`
+	MintHeight         uint32      `json:"mint_height"`
+	NftCoinID          Bytes32     `json:"nft_coin_id"`
+	OwnerDid           string      `json:"owner_did"`
+	PendingTransaction bool        `json:"pending_transaction"`
+	RoyaltyPercentage  uint32      `json:"royalty_percentage"`
+	RoyaltyPuzzleHash  *PuzzleHash `json:"royalty_puzzle_hash"`
+	EditionNumber      uint32      `json:"edition_number"`
+	EditionCount       uint32      `json:"edition_count"`
+	SupportsDid        bool        `json:"supports_did"`
+	UpdaterPuzhash     PuzzleHash  `json:"updater_puzhash"`
+}
+
+// NFTWalletWithDID an NFT wallet and the DID it is associated with
+type NFTWalletWithDID struct {
+	WalletID    uint32 `json:"wallet_id"`
+	DIDID       string `json:"did_id"`
+	DIDWalletID uint32 `json:"did_wallet_id"`
+}
+
+// NFTRoyaltyAsset an NFT that royalties are paid to, used in nft_calculate_royalties
+type NFTRoyaltyAsset struct {
+	Asset             string `json:"asset"`
+	RoyaltyAddress    string `json:"royalty_address"`
+	RoyaltyPercentage uint32 `json:"royalty_percentage"`
+}
+
+// NFTFungibleAsset a fungible asset that royalties are paid from, used in nft_calculate_royalties
+type NFTFungibleAsset struct {
+	Asset  string `json:"asset"`
+	Amount uint64 `json:"amount"`
+}
+
+// NFTRoyalty a single royalty payment for an NFT
+type NFTRoyalty struct {
+	Asset   string `json:"asset"`
+	Address string `json:"address"`
+	Amount  uint64 `json:"amount"`
+}
+
+// NFTBulkMetadata metadata for a single NFT when minting in bulk
+type NFTBulkMetadata struct {
+	URIs          []string `json:"uris"`
+	Hash          string   `json:"hash"`
+	MetaURIs      []string `json:"meta_uris,omitempty"`
+	MetaHash      string   `json:"meta_hash,omitempty"`
+	LicenseURIs   []string `json:"license_uris,omitempty"`
+	LicenseHash   string   `json:"license_hash,omitempty"`
+	EditionNumber uint32   `json:"edition_number,omitempty"`
+	EditionTotal  uint32   `json:"edition_total,omitempty"`
 }
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TradeStatus status of a trade/offer
+// These values match values in STAI blockchain. Must not be arbitrarily changed
+type TradeStatus uint8
+
+const (
+	// TradeStatusPendingAccept offer has been created, but not yet accepted
+	TradeStatusPendingAccept TradeStatus = 0
+
+	// TradeStatusPendingConfirm offer has been accepted and is waiting to be confirmed on chain
+	TradeStatusPendingConfirm TradeStatus = 1
+
+	// TradeStatusPendingCancel cancellation of the offer is waiting to be confirmed on chain
+	TradeStatusPendingCancel TradeStatus = 2
+
+	// TradeStatusCancelled offer has been cancelled
+	TradeStatusCancelled TradeStatus = 3
+
+	// TradeStatusConfirmed trade has been confirmed on chain
+	TradeStatusConfirmed TradeStatus = 4
+
+	// TradeStatusFailed trade failed
+	TradeStatusFailed TradeStatus = 5
+)
+
+var tradeStatusNames = map[TradeStatus]string{
+	TradeStatusPendingAccept:  "PENDING_ACCEPT",
+	TradeStatusPendingConfirm: "PENDING_CONFIRM",
+	TradeStatusPendingCancel:  "PENDING_CANCEL",
+	TradeStatusCancelled:      "CANCELLED",
+	TradeStatusConfirmed:      "CONFIRMED",
+	TradeStatusFailed:         "FAILED",
+}
+
+// String returns the name of the status, as used by STAI blockchain
+func (t TradeStatus) String() string {
+	if name, ok := tradeStatusNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", uint8(t))
+}
+
+// UnmarshalJSON unmarshals the trade status
+// The RPC returns the name of the status (PENDING_ACCEPT), but the numeric value is accepted as well
+func (t *TradeStatus) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var value uint8
+		if err = json.Unmarshal(data, &value); err != nil {
+			return fmt.Errorf("invalid trade status: %s", data)
+		}
+		*t = TradeStatus(value)
+		return nil
+	}
+
+	for status, statusName := range tradeStatusNames {
+		if statusName == name {
+			*t = status
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown trade status: %s", name)
+}
+
+// MarshalJSON marshals the trade status to its name
+func (t TradeStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// TradeRecord a single trade/offer, as returned by the wallet
+type TradeRecord struct {
+	ConfirmedAtIndex uint32           `json:"confirmed_at_index"`
+	AcceptedAtTime   *uint64          `json:"accepted_at_time"`
+	CreatedAtTime    uint64           `json:"created_at_time"` // @TODO time.Time?
+	IsMyOffer        bool             `json:"is_my_offer"`
+	Sent             uint32           `json:"sent"`
+	TakenOffer       *string          `json:"taken_offer"`
+	CoinsOfInterest  []*Coin          `json:"coins_of_interest"`
+	TradeID          Bytes32          `json:"trade_id"`
+	Status           TradeStatus      `json:"status"`
+	SentTo           []*SentTo        `json:"sent_to"`
+	Summary          *OfferSummary    `json:"summary"`
+	Pending          map[string]int64 `json:"pending"`
+}
+
+// OfferSummary summarizes the assets that are offered and requested in an offer
+// Keys of Offered and Requested are asset IDs, or the name of the native asset
+type OfferSummary struct {
+	Offered   map[string]int64           `json:"offered"`
+	Requested map[string]int64           `json:"requested"`
+	Fees      uint64                     `json:"fees"`
+	Infos     map[string]*OfferAssetInfo `json:"infos"`
+}
+
+// OfferAssetInfo describes the driver for a non-native asset in an offer
+type OfferAssetInfo struct {
+	Type       string          `json:"type"`
+	Tail       string          `json:"tail,omitempty"`
+	LauncherID string          `json:"launcher_id,omitempty"`
+	Also       json.RawMessage `json:"also,omitempty"`
+}
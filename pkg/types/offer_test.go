@@ -0,0 +1,40 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// TestTradeStatusFromName Ensures the status name returned by the RPC unmarshals correctly
+func TestTradeStatusFromName(t *testing.T) {
+	record := &types.TradeRecord{}
+	err := json.Unmarshal([]byte(`{"status":"PENDING_CONFIRM"}`), record)
+	assert.NoError(t, err)
+	assert.Equal(t, types.TradeStatusPendingConfirm, record.Status)
+}
+
+// TestTradeStatusFromValue Ensures numeric status values unmarshal correctly
+func TestTradeStatusFromValue(t *testing.T) {
+	record := &types.TradeRecord{}
+	err := json.Unmarshal([]byte(`{"status":3}`), record)
+	assert.NoError(t, err)
+	assert.Equal(t, types.TradeStatusCancelled, record.Status)
+}
+
+// TestTradeStatusUnknown Ensures unknown status names return an error
+func TestTradeStatusUnknown(t *testing.T) {
+	record := &types.TradeRecord{}
+	err := json.Unmarshal([]byte(`{"status":"NOT_A_STATUS"}`), record)
+	assert.Error(t, err)
+}
+
+// TestTradeStatusMarshal Ensures the status marshals back to its name
+func TestTradeStatusMarshal(t *testing.T) {
+	data, err := json.Marshal(types.TradeStatusConfirmed)
+	assert.NoError(t, err)
+	assert.Equal(t, `"CONFIRMED"`, string(data))
+}
@@ -0,0 +1,51 @@
+package types
+
+// PoolSingletonState the state of a pool singleton (plot NFT)
+// These values match values in STAI blockchain. Must not be arbitrarily changed
+type PoolSingletonState uint8
+
+const (
+	// PoolSingletonStateSelfPooling farming to a puzzle hash owned by the farmer
+	PoolSingletonStateSelfPooling PoolSingletonState = 1
+
+	// PoolSingletonStateLeavingPool waiting out the relative lock height before leaving a pool
+	PoolSingletonStateLeavingPool PoolSingletonState = 2
+
+	// PoolSingletonStateFarmingToPool farming to a pool
+	PoolSingletonStateFarmingToPool PoolSingletonState = 3
+)
+
+// String returns the name of the state, as used by STAI blockchain
+func (s PoolSingletonState) String() string {
+	switch s {
+	case PoolSingletonStateSelfPooling:
+		return "SELF_POOLING"
+	case PoolSingletonStateLeavingPool:
+		return "LEAVING_POOL"
+	case PoolSingletonStateFarmingToPool:
+		return "FARMING_TO_POOL"
+	}
+	return "UNKNOWN"
+}
+
+// PoolState the state of a pool singleton, stored on chain in the singleton's metadata
+type PoolState struct {
+	Version            uint8              `json:"version"`
+	State              PoolSingletonState `json:"state"`
+	TargetPuzzleHash   PuzzleHash         `json:"target_puzzle_hash"`
+	OwnerPubkey        G1Element          `json:"owner_pubkey"`
+	PoolURL            *string            `json:"pool_url"` // nil when self pooling
+	RelativeLockHeight uint32             `json:"relative_lock_height"`
+}
+
+// PoolWalletInfo the current state of a pool wallet, as returned by pw_status
+type PoolWalletInfo struct {
+	Current               PoolState         `json:"current"`
+	Target                *PoolState        `json:"target"` // Only present while transitioning between states
+	LauncherCoin          Coin              `json:"launcher_coin"`
+	LauncherID            Bytes32           `json:"launcher_id"`
+	P2SingletonPuzzleHash PuzzleHash        `json:"p2_singleton_puzzle_hash"`
+	CurrentInner          SerializedProgram `json:"current_inner"`
+	TipSingletonCoinID    Bytes32           `json:"tip_singleton_coin_id"`
+	SingletonBlockHeight  uint32            `json:"singleton_block_height"`
+}
@@ -5,3 +5,18 @@ type PoolTarget struct {
 	PuzzleHash *PuzzleHash `json:"puzzle_hash"`
 	MaxHeight  uint32      `json:"max_height"`
 }
+
+// Stream writes the pool target in the streamable format
+func (p *PoolTarget) Stream(w *StreamWriter) {
+	if w.required("puzzle_hash", p.PuzzleHash != nil) {
+		p.PuzzleHash.Stream(w)
+	}
+	w.WriteUint32(p.MaxHeight)
+}
+
+// Parse reads the pool target from the streamable format
+func (p *PoolTarget) Parse(r *StreamReader) {
+	p.PuzzleHash = new(PuzzleHash)
+	p.PuzzleHash.Parse(r)
+	p.MaxHeight = r.ReadUint32()
+}
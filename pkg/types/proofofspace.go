@@ -2,10 +2,47 @@ package types
 
 // ProofOfSpace Proof of Space
 type ProofOfSpace struct {
-	Challenge              string      `json:"challenge"`
+	Challenge              Bytes32     `json:"challenge"`
 	PoolPublicKey          *G1Element  `json:"pool_public_key"` // Only one of these two should be present
 	PoolContractPuzzleHash *PuzzleHash `json:"pool_contract_puzzle_hash"`
 	PlotPublicKey          *G1Element  `json:"plot_public_key"`
 	Size                   uint8       `json:"size"`
 	Proof                  string      `json:"proof"`
 }
+
+// Stream writes the proof of space in the streamable format
+func (p *ProofOfSpace) Stream(w *StreamWriter) {
+	w.WriteBytes32(p.Challenge)
+	w.WriteOptional(p.PoolPublicKey != nil)
+	if p.PoolPublicKey != nil {
+		p.PoolPublicKey.Stream(w)
+	}
+	w.WriteOptional(p.PoolContractPuzzleHash != nil)
+	if p.PoolContractPuzzleHash != nil {
+		p.PoolContractPuzzleHash.Stream(w)
+	}
+	if w.required("plot_public_key", p.PlotPublicKey != nil) {
+		p.PlotPublicKey.Stream(w)
+	}
+	w.WriteUint8(p.Size)
+	w.WriteBytes(p.Proof)
+}
+
+// Parse reads the proof of space from the streamable format
+func (p *ProofOfSpace) Parse(r *StreamReader) {
+	p.Challenge = r.ReadBytes32()
+	p.PoolPublicKey = nil
+	if r.ReadOptional() {
+		p.PoolPublicKey = new(G1Element)
+		p.PoolPublicKey.Parse(r)
+	}
+	p.PoolContractPuzzleHash = nil
+	if r.ReadOptional() {
+		p.PoolContractPuzzleHash = new(PuzzleHash)
+		p.PoolContractPuzzleHash.Parse(r)
+	}
+	p.PlotPublicKey = new(G1Element)
+	p.PlotPublicKey.Parse(r)
+	p.Size = r.ReadUint8()
+	p.Proof = r.ReadBytes()
+}
@@ -0,0 +1,487 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sizes of the fixed length byte types used in the streamable format
+const (
+	Bytes32Size           = 32
+	G1ElementSize         = 48
+	G2ElementSize         = 96
+	ClassgroupElementSize = 100
+)
+
+// ErrStreamTruncated is returned when the data ends before the value is fully parsed
+var ErrStreamTruncated = errors.New("streamable data is truncated")
+
+// Streamable is implemented by types that can be encoded with the STAI streamable binary format
+// Integers are big-endian, lists and variable length bytes are prefixed with a uint32 length, and
+// optional values are prefixed with a bool indicating whether the value is present
+type Streamable interface {
+	Stream(w *StreamWriter)
+	Parse(r *StreamReader)
+}
+
+// ToBytes encodes the value with the streamable format
+func ToBytes(value Streamable) ([]byte, error) {
+	w := &StreamWriter{}
+	value.Stream(w)
+	if w.err != nil {
+		return nil, w.err
+	}
+	return w.buf, nil
+}
+
+// FromBytes decodes the streamable encoded data into value
+// All the data must be consumed, otherwise an error is returned
+func FromBytes(data []byte, value Streamable) error {
+	r := NewStreamReader(data)
+	value.Parse(r)
+	if r.err != nil {
+		return r.err
+	}
+	if r.Remaining() != 0 {
+		return fmt.Errorf("%d unexpected bytes after streamable value", r.Remaining())
+	}
+	return nil
+}
+
+// StreamWriter builds streamable encoded data
+// The first error encountered is kept and all further writes are ignored. Check Err when done
+type StreamWriter struct {
+	buf []byte
+	err error
+}
+
+// Data returns the encoded data
+func (w *StreamWriter) Data() []byte {
+	return w.buf
+}
+
+// Err returns the first error encountered while writing
+func (w *StreamWriter) Err() error {
+	return w.err
+}
+
+// fail records the error, unless an error was already recorded
+func (w *StreamWriter) fail(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+// required records an error if a required field is missing, and returns whether the field is present
+func (w *StreamWriter) required(name string, present bool) bool {
+	if !present {
+		w.fail(fmt.Errorf("required field %s is missing", name))
+	}
+	return present && w.err == nil
+}
+
+// WriteUint8 writes a uint8
+func (w *StreamWriter) WriteUint8(v uint8) {
+	if w.err != nil {
+		return
+	}
+	w.buf = append(w.buf, v)
+}
+
+// WriteUint16 writes a big-endian uint16
+func (w *StreamWriter) WriteUint16(v uint16) {
+	if w.err != nil {
+		return
+	}
+	w.buf = append(w.buf, byte(v>>8), byte(v))
+}
+
+// WriteUint32 writes a big-endian uint32
+func (w *StreamWriter) WriteUint32(v uint32) {
+	if w.err != nil {
+		return
+	}
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], v)
+	w.buf = append(w.buf, b[:]...)
+}
+
+// WriteUint64 writes a big-endian uint64
+func (w *StreamWriter) WriteUint64(v uint64) {
+	if w.err != nil {
+		return
+	}
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	w.buf = append(w.buf, b[:]...)
+}
+
+// WriteUint128 writes a big-endian uint128
+func (w *StreamWriter) WriteUint128(v Uint128) {
+	w.WriteUint64(v.Hi)
+	w.WriteUint64(v.Lo)
+}
+
+// WriteBool writes a bool as a single byte
+func (w *StreamWriter) WriteBool(v bool) {
+	if v {
+		w.WriteUint8(1)
+	} else {
+		w.WriteUint8(0)
+	}
+}
+
+// WriteOptional writes the marker for an optional value. When present, the value must be written next
+func (w *StreamWriter) WriteOptional(present bool) {
+	w.WriteBool(present)
+}
+
+// WriteListLength writes the number of items in a list. The items must be written next
+func (w *StreamWriter) WriteListLength(length int) {
+	if uint64(length) > 0xffffffff {
+		w.fail(fmt.Errorf("list length %d is too long", length))
+		return
+	}
+	w.WriteUint32(uint32(length))
+}
+
+// WriteRaw writes the bytes without a length prefix
+func (w *StreamWriter) WriteRaw(b []byte) {
+	if w.err != nil {
+		return
+	}
+	w.buf = append(w.buf, b...)
+}
+
+// WriteBytes writes hex encoded variable length bytes, prefixed with a uint32 length
+func (w *StreamWriter) WriteBytes(hexStr string) {
+	b, err := decodeHexString(hexStr)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	w.WriteListLength(len(b))
+	w.WriteRaw(b)
+}
+
+// WriteFixedBytes writes hex encoded bytes that must be exactly size bytes long
+func (w *StreamWriter) WriteFixedBytes(hexStr string, size int) {
+	b, err := decodeHexString(hexStr)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	if len(b) != size {
+		w.fail(fmt.Errorf("expected %d bytes, got %d", size, len(b)))
+		return
+	}
+	w.WriteRaw(b)
+}
+
+// WriteBytes32 writes a bytes32
+func (w *StreamWriter) WriteBytes32(v Bytes32) {
+	w.WriteRaw(v[:])
+}
+
+// WriteProgram writes a hex encoded serialized CLVM program
+// Programs are self delimiting, so they are not prefixed with a length
+func (w *StreamWriter) WriteProgram(hexStr string) {
+	b, err := decodeHexString(hexStr)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	length, err := serializedProgramLength(b)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	if length != len(b) {
+		w.fail(fmt.Errorf("serialized program has %d unexpected bytes at the end", len(b)-length))
+		return
+	}
+	w.WriteRaw(b)
+}
+
+// StreamReader parses streamable encoded data
+// The first error encountered is kept and all further reads return zero values. Check Err when done
+type StreamReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+// NewStreamReader returns a reader for the streamable encoded data
+func NewStreamReader(data []byte) *StreamReader {
+	return &StreamReader{data: data}
+}
+
+// Err returns the first error encountered while reading
+func (r *StreamReader) Err() error {
+	return r.err
+}
+
+// Remaining returns the number of bytes that have not been read yet
+func (r *StreamReader) Remaining() int {
+	return len(r.data) - r.pos
+}
+
+// fail records the error, unless an error was already recorded
+func (r *StreamReader) fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// next returns the next n bytes, or nil if there are not enough bytes left
+func (r *StreamReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > r.Remaining() {
+		r.fail(ErrStreamTruncated)
+		return nil
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+// ReadUint8 reads a uint8
+func (r *StreamReader) ReadUint8() uint8 {
+	b := r.next(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+// ReadUint16 reads a big-endian uint16
+func (r *StreamReader) ReadUint16() uint16 {
+	b := r.next(2)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint16(b)
+}
+
+// ReadUint32 reads a big-endian uint32
+func (r *StreamReader) ReadUint32() uint32 {
+	b := r.next(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+// ReadUint64 reads a big-endian uint64
+func (r *StreamReader) ReadUint64() uint64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+// ReadUint128 reads a big-endian uint128
+func (r *StreamReader) ReadUint128() Uint128 {
+	hi := r.ReadUint64()
+	lo := r.ReadUint64()
+	return NewUint128(lo, hi)
+}
+
+// ReadBool reads a bool, which must be encoded as 0 or 1
+func (r *StreamReader) ReadBool() bool {
+	v := r.ReadUint8()
+	if v > 1 {
+		r.fail(fmt.Errorf("invalid bool value %d", v))
+		return false
+	}
+	return v == 1
+}
+
+// ReadOptional reads the marker for an optional value, and returns whether the value is present
+func (r *StreamReader) ReadOptional() bool {
+	return r.ReadBool()
+}
+
+// ReadListLength reads the number of items in a list
+// Every item takes at least one byte, so lengths longer than the remaining data are rejected
+func (r *StreamReader) ReadListLength() int {
+	length := r.ReadUint32()
+	if r.err != nil {
+		return 0
+	}
+	if uint64(length) > uint64(r.Remaining()) {
+		r.fail(ErrStreamTruncated)
+		return 0
+	}
+	return int(length)
+}
+
+// ReadRaw reads n bytes
+func (r *StreamReader) ReadRaw(n int) []byte {
+	b := r.next(n)
+	if b == nil {
+		return nil
+	}
+	out := make([]byte, n)
+	copy(out, b)
+	return out
+}
+
+// ReadBytes reads variable length bytes prefixed with a uint32 length, and returns them 0x prefixed hex encoded
+func (r *StreamReader) ReadBytes() string {
+	length := r.ReadUint32()
+	b := r.next(int(length))
+	if b == nil {
+		return ""
+	}
+	return encodeHexString(b)
+}
+
+// ReadFixedBytes reads size bytes, and returns them 0x prefixed hex encoded
+func (r *StreamReader) ReadFixedBytes(size int) string {
+	b := r.next(size)
+	if b == nil {
+		return ""
+	}
+	return encodeHexString(b)
+}
+
+// ReadBytes32 reads a bytes32
+func (r *StreamReader) ReadBytes32() Bytes32 {
+	var v Bytes32
+	copy(v[:], r.next(Bytes32Size))
+	return v
+}
+
+// ReadProgram reads a serialized CLVM program, and returns it 0x prefixed hex encoded
+func (r *StreamReader) ReadProgram() string {
+	if r.err != nil {
+		return ""
+	}
+	length, err := serializedProgramLength(r.data[r.pos:])
+	if err != nil {
+		r.fail(err)
+		return ""
+	}
+	return r.ReadFixedBytes(length)
+}
+
+// decodeHexString decodes hex, with or without the 0x prefix
+func decodeHexString(hexStr string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(hexStr, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex %q: %w", hexStr, err)
+	}
+	return b, nil
+}
+
+// encodeHexString encodes bytes as 0x prefixed hex, the same way the RPCs return bytes
+func encodeHexString(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// serializedProgramLength returns the length of the serialized CLVM program at the start of data
+func serializedProgramLength(data []byte) (int, error) {
+	pos := 0
+	// Number of programs left to read. Each pair adds its left and right side
+	pending := 1
+	for pending > 0 {
+		pending--
+		if pos >= len(data) {
+			return 0, ErrStreamTruncated
+		}
+
+		b := data[pos]
+		pos++
+		if b == 0xff {
+			pending += 2
+			continue
+		}
+		if b <= 0x80 {
+			// Single byte atom, or nil
+			continue
+		}
+
+		// The number of leading one bits is the number of bytes used to encode the atom size
+		prefixLen := 0
+		for mask := byte(0x80); b&mask != 0; mask >>= 1 {
+			b &^= mask
+			prefixLen++
+		}
+		if prefixLen > 5 {
+			return 0, errors.New("invalid serialized program atom size")
+		}
+		if pos+prefixLen-1 > len(data) {
+			return 0, ErrStreamTruncated
+		}
+		size := uint64(b)
+		for _, sizeByte := range data[pos : pos+prefixLen-1] {
+			size = size<<8 | uint64(sizeByte)
+		}
+		pos += prefixLen - 1
+		if size > uint64(len(data)-pos) {
+			return 0, ErrStreamTruncated
+		}
+		pos += int(size)
+	}
+
+	return pos, nil
+}
+
+// streamOptionalBytes32 writes an optional bytes32
+func streamOptionalBytes32(w *StreamWriter, v *Bytes32) {
+	w.WriteOptional(v != nil)
+	if v != nil {
+		w.WriteBytes32(*v)
+	}
+}
+
+// parseOptionalBytes32 reads an optional bytes32
+func parseOptionalBytes32(r *StreamReader) *Bytes32 {
+	if !r.ReadOptional() {
+		return nil
+	}
+	v := r.ReadBytes32()
+	return &v
+}
+
+// streamOptionalUint64 writes an optional uint64
+func streamOptionalUint64(w *StreamWriter, v *uint64) {
+	w.WriteOptional(v != nil)
+	if v != nil {
+		w.WriteUint64(*v)
+	}
+}
+
+// parseOptionalUint64 reads an optional uint64
+func parseOptionalUint64(r *StreamReader) *uint64 {
+	if !r.ReadOptional() {
+		return nil
+	}
+	v := r.ReadUint64()
+	return &v
+}
+
+// streamBytes32List writes a list of bytes32
+func streamBytes32List(w *StreamWriter, list []Bytes32) {
+	w.WriteListLength(len(list))
+	for _, item := range list {
+		w.WriteBytes32(item)
+	}
+}
+
+// parseBytes32List reads a list of bytes32
+func parseBytes32List(r *StreamReader) []Bytes32 {
+	length := r.ReadListLength()
+	list := make([]Bytes32, 0, length)
+	for i := 0; i < length && r.Err() == nil; i++ {
+		list = append(list, r.ReadBytes32())
+	}
+	return list
+}
@@ -0,0 +1,243 @@
+package types_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+func hexBytes(b byte, size int) string {
+	return "0x" + strings.Repeat(hex.EncodeToString([]byte{b}), size)
+}
+
+func b32(b byte) types.Bytes32 {
+	var v types.Bytes32
+	for i := range v {
+		v[i] = b
+	}
+	return v
+}
+
+func b32Ptr(b byte) *types.Bytes32 {
+	v := b32(b)
+	return &v
+}
+
+func TestCoinStreamable(t *testing.T) {
+	coin := &types.Coin{
+		ParentCoinInfo: b32(0x11),
+		PuzzleHash:     types.PuzzleHash(b32(0x22)),
+		Amount:         types.Uint128From64(1750000000000),
+	}
+
+	data, err := types.ToBytes(coin)
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("11", 32)+strings.Repeat("22", 32)+"000001977420dc00", hex.EncodeToString(data))
+
+	decoded := &types.Coin{}
+	assert.NoError(t, types.FromBytes(data, decoded))
+	assert.Equal(t, coin, decoded)
+
+	// Amounts are uint64 on chain
+	coin.Amount = types.NewUint128(0, 1)
+	_, err = types.ToBytes(coin)
+	assert.Error(t, err)
+}
+
+func TestSpendBundleStreamable(t *testing.T) {
+	puzzle := types.SerializedProgram("0xff01ff8300aabb80")
+	solution := types.SerializedProgram("0x80")
+	bundle := &types.SpendBundle{
+		CoinSolutions: []*types.CoinSolution{
+			{
+				Coin:         &types.Coin{ParentCoinInfo: b32(0x01), PuzzleHash: types.PuzzleHash(b32(0x02)), Amount: types.Uint128From64(1)},
+				PuzzleReveal: &puzzle,
+				Solution:     &solution,
+			},
+		},
+		AggregatedSignature: types.G2Element(hexBytes(0xc0, 96)),
+	}
+
+	data, err := types.ToBytes(bundle)
+	assert.NoError(t, err)
+	assert.Len(t, data, 4+32+32+8+8+1+96)
+
+	decoded := &types.SpendBundle{}
+	assert.NoError(t, types.FromBytes(data, decoded))
+	assert.Equal(t, bundle, decoded)
+
+	// Trailing and missing data are both errors
+	assert.Error(t, types.FromBytes(append(data, 0), &types.SpendBundle{}))
+	assert.ErrorIs(t, types.FromBytes(data[:len(data)-1], &types.SpendBundle{}), types.ErrStreamTruncated)
+
+	// Programs must be exactly one complete program
+	badPuzzle := types.SerializedProgram("0xff01")
+	bundle.CoinSolutions[0].PuzzleReveal = &badPuzzle
+	_, err = types.ToBytes(bundle)
+	assert.Error(t, err)
+}
+
+func TestFullBlockStreamable(t *testing.T) {
+	classgroup := &types.ClassgroupElement{Data: hexBytes(0x08, 100)}
+	vdf := &types.VDFInfo{Challenge: b32(0x09), NumberOfIterations: 123456, Output: classgroup}
+	proof := &types.VDFProof{WitnessType: 0, Witness: "0x0102", NormalizedToIdentity: true}
+	signature := types.G2Element(hexBytes(0xc0, 96))
+	plotKey := types.G1Element(hexBytes(0xa0, 48))
+	poolPuzzleHash := types.PuzzleHash(b32(0x03))
+	generator := types.SerializedProgram("0xff0180")
+	newIters := uint64(1000)
+
+	block := &types.FullBlock{
+		FinishedSubSlots: []*types.EndOfSubSlotBundle{
+			{
+				ChallengeChain: &types.ChallengeChainSubSlot{
+					ChallengeChainEndOfSlotVDF: vdf,
+					SubepochSummaryHash:        b32Ptr(0x04),
+					NewSubSlotIters:            &newIters,
+				},
+				RewardChain: &types.RewardChainSubSlot{
+					EndOfSlotVDF:              vdf,
+					ChallengeChainSubSlotHash: b32(0x05),
+					Deficit:                   16,
+				},
+				Proofs: &types.SubSlotProofs{
+					ChallengeChainSlotProof: proof,
+					RewardChainSlotProof:    proof,
+				},
+			},
+		},
+		RewardChainBlock: &types.RewardChainBlock{
+			Weight:               types.NewUint128(5, 1),
+			Height:               100,
+			TotalIters:           types.Uint128From64(99999),
+			SignagePointIndex:    4,
+			POSSSCCChallengeHash: b32(0x06),
+			ProofOfSpace: &types.ProofOfSpace{
+				Challenge:              b32(0x07),
+				PoolContractPuzzleHash: &poolPuzzleHash,
+				PlotPublicKey:          &plotKey,
+				Size:                   32,
+				Proof:                  "0xdeadbeef",
+			},
+			ChallengeChainSPSignature: &signature,
+			ChallengeChainIPVDF:       vdf,
+			RewardChainSPVDF:          vdf,
+			RewardChainSPSignature:    &signature,
+			RewardChainIPVDF:          vdf,
+			IsTransactionBlock:        true,
+		},
+		ChallengeChainIPProof: proof,
+		RewardChainSPProof:    proof,
+		RewardChainIPProof:    proof,
+		Foliage: &types.Foliage{
+			PrevBlockHash:   b32(0x0a),
+			RewardBlockHash: b32(0x0b),
+			FoliageBlockData: &types.FoliageBlockData{
+				UnfinishedRewardBlockHash: b32(0x0c),
+				PoolTarget:                &types.PoolTarget{PuzzleHash: &poolPuzzleHash, MaxHeight: 0},
+				PoolSignature:             &signature,
+				FarmerRewardPuzzleHash:    types.PuzzleHash(b32(0x0d)),
+				ExtensionData:             b32(0x00),
+			},
+			FoliageBlockDataSignature:        &signature,
+			FoliageTransactionBlockHash:      b32Ptr(0x0e),
+			FoliageTransactionBlockSignature: &signature,
+		},
+		FoliageTransactionBlock: &types.FoliageTransactionBlock{
+			PrevTransactionBlockHash: b32(0x0f),
+			Timestamp:                1650000000,
+			FilterHash:               b32(0x10),
+			AdditionsRoot:            b32(0x11),
+			RemovalsRoot:             b32(0x12),
+			TransactionsInfoHash:     b32(0x13),
+		},
+		TransactionsInfo: &types.TransactionsInfo{
+			GeneratorRoot:       b32(0x14),
+			GeneratorRefsRoot:   b32(0x15),
+			AggregatedSignature: &signature,
+			Fees:                10,
+			Cost:                20,
+			RewardClaimsIncorporated: []*types.Coin{
+				{ParentCoinInfo: b32(0x16), PuzzleHash: types.PuzzleHash(b32(0x17)), Amount: types.Uint128From64(250000000)},
+			},
+		},
+		TransactionsGenerator:        &generator,
+		TransactionsGeneratorRefList: []uint32{1, 2, 3},
+	}
+
+	data, err := types.ToBytes(block)
+	assert.NoError(t, err)
+
+	decoded := &types.FullBlock{}
+	assert.NoError(t, types.FromBytes(data, decoded))
+	assert.Equal(t, block, decoded)
+
+	// Required fields must be set
+	block.Foliage = nil
+	_, err = types.ToBytes(block)
+	assert.Error(t, err)
+}
+
+func TestBlockRecordStreamable(t *testing.T) {
+	puzzleHash := types.PuzzleHash(b32(0x01))
+	record := &types.BlockRecord{
+		HeaderHash:                 b32(0x02),
+		PrevHash:                   b32(0x03),
+		Height:                     12,
+		Weight:                     types.Uint128From64(1000),
+		TotalIters:                 types.Uint128From64(2000),
+		SignagePointIndex:          3,
+		ChallengeVDFOutput:         &types.ClassgroupElement{Data: hexBytes(0x04, 100)},
+		RewardInfusionNewChallenge: b32(0x05),
+		ChallengeBlockInfoHash:     b32(0x06),
+		SubSlotIters:               147849216,
+		PoolPuzzleHash:             &puzzleHash,
+		FarmerPuzzleHash:           &puzzleHash,
+		RequiredIters:              1234,
+		Deficit:                    15,
+		PrevTransactionBlockHeight: 11,
+		FinishedChallengeSlotHashes: []types.Bytes32{
+			b32(0x07),
+		},
+		SubEpochSummaryIncluded: &types.SubEpochSummary{
+			PrevSubEpochSummaryHash: b32(0x08),
+			RewardChainHash:         b32(0x09),
+			NumBlocksOverflow:       1,
+			NewDifficulty:           300,
+		},
+	}
+
+	data, err := types.ToBytes(record)
+	assert.NoError(t, err)
+	decoded := &types.BlockRecord{}
+	assert.NoError(t, types.FromBytes(data, decoded))
+	assert.Equal(t, record, decoded)
+
+	// Transaction block fields
+	record.Timestamp = 1650000000
+	record.PrevTransactionBlockHash = b32Ptr(0x0a)
+	record.Fees = 5
+	record.RewardClaimsIncorporated = []*types.Coin{}
+	data, err = types.ToBytes(record)
+	assert.NoError(t, err)
+	decoded = &types.BlockRecord{}
+	assert.NoError(t, types.FromBytes(data, decoded))
+	assert.Equal(t, record, decoded)
+}
+
+func TestStreamReaderInvalidBool(t *testing.T) {
+	r := types.NewStreamReader([]byte{2})
+	r.ReadBool()
+	assert.Error(t, r.Err())
+}
+
+func TestStreamReaderListLengthTooLong(t *testing.T) {
+	// Claims more items than there is data for
+	r := types.NewStreamReader([]byte{0xff, 0xff, 0xff, 0xff, 0x00})
+	assert.Equal(t, 0, r.ReadListLength())
+	assert.ErrorIs(t, r.Err(), types.ErrStreamTruncated)
+}
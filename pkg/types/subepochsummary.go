@@ -2,9 +2,40 @@ package types
 
 // SubEpochSummary sub epoch summary
 type SubEpochSummary struct {
-	PrevSubEpochSummaryHash string `json:"prev_subepoch_summary_hash"`
-	RewardChainHash         string `json:"reward_chain_hash"`
-	NumBlocksOverflow       uint8  `json:"num_blocks_overflow"`
-	NewDifficulty           uint64 `json:"new_difficulty"`
-	NewSubSlotIters         uint64 `json:"new_sub_slot_iters"`
+	PrevSubEpochSummaryHash Bytes32 `json:"prev_subepoch_summary_hash"`
+	RewardChainHash         Bytes32 `json:"reward_chain_hash"`
+	NumBlocksOverflow       uint8   `json:"num_blocks_overflow"`
+	NewDifficulty           uint64  `json:"new_difficulty"`
+	NewSubSlotIters         uint64  `json:"new_sub_slot_iters"`
+}
+
+// Stream writes the sub epoch summary in the streamable format
+// NewDifficulty and NewSubSlotIters are optional, and are not present when 0
+func (s *SubEpochSummary) Stream(w *StreamWriter) {
+	w.WriteBytes32(s.PrevSubEpochSummaryHash)
+	w.WriteBytes32(s.RewardChainHash)
+	w.WriteUint8(s.NumBlocksOverflow)
+	w.WriteOptional(s.NewDifficulty != 0)
+	if s.NewDifficulty != 0 {
+		w.WriteUint64(s.NewDifficulty)
+	}
+	w.WriteOptional(s.NewSubSlotIters != 0)
+	if s.NewSubSlotIters != 0 {
+		w.WriteUint64(s.NewSubSlotIters)
+	}
+}
+
+// Parse reads the sub epoch summary from the streamable format
+func (s *SubEpochSummary) Parse(r *StreamReader) {
+	s.PrevSubEpochSummaryHash = r.ReadBytes32()
+	s.RewardChainHash = r.ReadBytes32()
+	s.NumBlocksOverflow = r.ReadUint8()
+	s.NewDifficulty = 0
+	if r.ReadOptional() {
+		s.NewDifficulty = r.ReadUint64()
+	}
+	s.NewSubSlotIters = 0
+	if r.ReadOptional() {
+		s.NewSubSlotIters = r.ReadUint64()
+	}
 }
@@ -0,0 +1,143 @@
+package types
+
+// EndOfSubSlotBundle end of subslot bundle
+type EndOfSubSlotBundle struct {
+	ChallengeChain        *ChallengeChainSubSlot        `json:"challenge_chain"`
+	InfusedChallengeChain *InfusedChallengeChainSubSlot `json:"infused_challenge_chain"`
+	RewardChain           *RewardChainSubSlot           `json:"reward_chain"`
+	Proofs                *SubSlotProofs                `json:"proofs"`
+}
+
+// ChallengeChainSubSlot challenge chain sub slot
+type ChallengeChainSubSlot struct {
+	ChallengeChainEndOfSlotVDF       *VDFInfo `json:"challenge_chain_end_of_slot_vdf"`
+	InfusedChallengeChainSubSlotHash *Bytes32 `json:"infused_challenge_chain_sub_slot_hash"` // Only at the end of a slot
+	SubepochSummaryHash              *Bytes32 `json:"subepoch_summary_hash"`                 // Only once per sub-epoch, and one sub-epoch delayed
+	NewSubSlotIters                  *uint64  `json:"new_sub_slot_iters"`                    // Only at the end of epoch, sub-epoch, and slot
+	NewDifficulty                    *uint64  `json:"new_difficulty"`                        // Only at the end of epoch, sub-epoch, and slot
+}
+
+// InfusedChallengeChainSubSlot infused challenge chain sub slot
+type InfusedChallengeChainSubSlot struct {
+	InfusedChallengeChainEndOfSlotVDF *VDFInfo `json:"infused_challenge_chain_end_of_slot_vdf"`
+}
+
+// RewardChainSubSlot reward chain sub slot
+type RewardChainSubSlot struct {
+	EndOfSlotVDF                     *VDFInfo `json:"end_of_slot_vdf"`
+	ChallengeChainSubSlotHash        Bytes32  `json:"challenge_chain_sub_slot_hash"`
+	InfusedChallengeChainSubSlotHash *Bytes32 `json:"infused_challenge_chain_sub_slot_hash"`
+	Deficit                          uint8    `json:"deficit"`
+}
+
+// SubSlotProofs sub slot proofs
+type SubSlotProofs struct {
+	ChallengeChainSlotProof        *VDFProof `json:"challenge_chain_slot_proof"`
+	InfusedChallengeChainSlotProof *VDFProof `json:"infused_challenge_chain_slot_proof"`
+	RewardChainSlotProof           *VDFProof `json:"reward_chain_slot_proof"`
+}
+
+// Stream writes the end of sub slot bundle in the streamable format
+func (e *EndOfSubSlotBundle) Stream(w *StreamWriter) {
+	if w.required("challenge_chain", e.ChallengeChain != nil) {
+		e.ChallengeChain.Stream(w)
+	}
+	w.WriteOptional(e.InfusedChallengeChain != nil)
+	if e.InfusedChallengeChain != nil {
+		e.InfusedChallengeChain.Stream(w)
+	}
+	if w.required("reward_chain", e.RewardChain != nil) {
+		e.RewardChain.Stream(w)
+	}
+	if w.required("proofs", e.Proofs != nil) {
+		e.Proofs.Stream(w)
+	}
+}
+
+// Parse reads the end of sub slot bundle from the streamable format
+func (e *EndOfSubSlotBundle) Parse(r *StreamReader) {
+	e.ChallengeChain = &ChallengeChainSubSlot{}
+	e.ChallengeChain.Parse(r)
+	e.InfusedChallengeChain = nil
+	if r.ReadOptional() {
+		e.InfusedChallengeChain = &InfusedChallengeChainSubSlot{}
+		e.InfusedChallengeChain.Parse(r)
+	}
+	e.RewardChain = &RewardChainSubSlot{}
+	e.RewardChain.Parse(r)
+	e.Proofs = &SubSlotProofs{}
+	e.Proofs.Parse(r)
+}
+
+// Stream writes the challenge chain sub slot in the streamable format
+func (c *ChallengeChainSubSlot) Stream(w *StreamWriter) {
+	if w.required("challenge_chain_end_of_slot_vdf", c.ChallengeChainEndOfSlotVDF != nil) {
+		c.ChallengeChainEndOfSlotVDF.Stream(w)
+	}
+	streamOptionalBytes32(w, c.InfusedChallengeChainSubSlotHash)
+	streamOptionalBytes32(w, c.SubepochSummaryHash)
+	streamOptionalUint64(w, c.NewSubSlotIters)
+	streamOptionalUint64(w, c.NewDifficulty)
+}
+
+// Parse reads the challenge chain sub slot from the streamable format
+func (c *ChallengeChainSubSlot) Parse(r *StreamReader) {
+	c.ChallengeChainEndOfSlotVDF = &VDFInfo{}
+	c.ChallengeChainEndOfSlotVDF.Parse(r)
+	c.InfusedChallengeChainSubSlotHash = parseOptionalBytes32(r)
+	c.SubepochSummaryHash = parseOptionalBytes32(r)
+	c.NewSubSlotIters = parseOptionalUint64(r)
+	c.NewDifficulty = parseOptionalUint64(r)
+}
+
+// Stream writes the infused challenge chain sub slot in the streamable format
+func (i *InfusedChallengeChainSubSlot) Stream(w *StreamWriter) {
+	if w.required("infused_challenge_chain_end_of_slot_vdf", i.InfusedChallengeChainEndOfSlotVDF != nil) {
+		i.InfusedChallengeChainEndOfSlotVDF.Stream(w)
+	}
+}
+
+// Parse reads the infused challenge chain sub slot from the streamable format
+func (i *InfusedChallengeChainSubSlot) Parse(r *StreamReader) {
+	i.InfusedChallengeChainEndOfSlotVDF = &VDFInfo{}
+	i.InfusedChallengeChainEndOfSlotVDF.Parse(r)
+}
+
+// Stream writes the reward chain sub slot in the streamable format
+func (s *RewardChainSubSlot) Stream(w *StreamWriter) {
+	if w.required("end_of_slot_vdf", s.EndOfSlotVDF != nil) {
+		s.EndOfSlotVDF.Stream(w)
+	}
+	w.WriteBytes32(s.ChallengeChainSubSlotHash)
+	streamOptionalBytes32(w, s.InfusedChallengeChainSubSlotHash)
+	w.WriteUint8(s.Deficit)
+}
+
+// Parse reads the reward chain sub slot from the streamable format
+func (s *RewardChainSubSlot) Parse(r *StreamReader) {
+	s.EndOfSlotVDF = &VDFInfo{}
+	s.EndOfSlotVDF.Parse(r)
+	s.ChallengeChainSubSlotHash = r.ReadBytes32()
+	s.InfusedChallengeChainSubSlotHash = parseOptionalBytes32(r)
+	s.Deficit = r.ReadUint8()
+}
+
+// Stream writes the sub slot proofs in the streamable format
+func (p *SubSlotProofs) Stream(w *StreamWriter) {
+	if w.required("challenge_chain_slot_proof", p.ChallengeChainSlotProof != nil) {
+		p.ChallengeChainSlotProof.Stream(w)
+	}
+	streamOptionalVDFProof(w, p.InfusedChallengeChainSlotProof)
+	if w.required("reward_chain_slot_proof", p.RewardChainSlotProof != nil) {
+		p.RewardChainSlotProof.Stream(w)
+	}
+}
+
+// Parse reads the sub slot proofs from the streamable format
+func (p *SubSlotProofs) Parse(r *StreamReader) {
+	p.ChallengeChainSlotProof = &VDFProof{}
+	p.ChallengeChainSlotProof.Parse(r)
+	p.InfusedChallengeChainSlotProof = parseOptionalVDFProof(r)
+	p.RewardChainSlotProof = &VDFProof{}
+	p.RewardChainSlotProof.Parse(r)
+}
@@ -21,12 +21,14 @@ type TransactionRecord struct {
 	SentTo            []*SentTo        `json:"sent_to"`
 	TradeID           string           `json:"trade_id"`
 	Type              *TransactionType `json:"type"`
-	Name              string           `json:"name"` // @TODO bytes32 / hex
+	Name              Bytes32          `json:"name"`
+	// Memos maps coin IDs to the list of hex encoded memos attached to the coin
+	Memos map[string][]string `json:"memos"`
 	// ToAddress is not on the official type, but some endpoints return it anyways
 	ToAddress *Address `json:"to_address"`
 }
 
-// Address Own type for future methods to encode/decode
+// Address is a bech32m encoded puzzle hash, with a prefix for the network it belongs to
 type Address string
 
 // SentTo Represents the list of peers that we sent the transaction to, whether each one
@@ -91,6 +93,51 @@ const (
 
 // SpendBundle Spend Bundle...
 type SpendBundle struct {
-	AggregatedSignature string          `json:"aggregated_signature"`
+	AggregatedSignature G2Element       `json:"aggregated_signature"`
 	CoinSolutions       []*CoinSolution `json:"coin_solutions"`
 }
+
+// Addition is a single payment output used when creating transactions with multiple outputs
+type Addition struct {
+	Amount     uint64     `json:"amount"`
+	PuzzleHash PuzzleHash `json:"puzzle_hash"`
+	Memos      []string   `json:"memos,omitempty"`
+}
+
+// CoinAnnouncement is a coin announcement to assert when creating a transaction
+type CoinAnnouncement struct {
+	CoinID     string `json:"coin_id"`
+	Message    string `json:"message"`
+	MorphBytes string `json:"morph_bytes,omitempty"`
+}
+
+// PuzzleAnnouncement is a puzzle announcement to assert when creating a transaction
+type PuzzleAnnouncement struct {
+	PuzzleHash PuzzleHash `json:"puzzle_hash"`
+	Message    string     `json:"message"`
+	MorphBytes string     `json:"morph_bytes,omitempty"`
+}
+
+// Stream writes the spend bundle in the streamable format
+func (s *SpendBundle) Stream(w *StreamWriter) {
+	w.WriteListLength(len(s.CoinSolutions))
+	for _, solution := range s.CoinSolutions {
+		if !w.required("coin_solution", solution != nil) {
+			return
+		}
+		solution.Stream(w)
+	}
+	s.AggregatedSignature.Stream(w)
+}
+
+// Parse reads the spend bundle from the streamable format
+func (s *SpendBundle) Parse(r *StreamReader) {
+	length := r.ReadListLength()
+	s.CoinSolutions = make([]*CoinSolution, 0, length)
+	for i := 0; i < length && r.Err() == nil; i++ {
+		solution := &CoinSolution{}
+		solution.Parse(r)
+		s.CoinSolutions = append(s.CoinSolutions, solution)
+	}
+	s.AggregatedSignature.Parse(r)
+}
@@ -1,7 +1,45 @@
 package types
 
-// PuzzleHash Own type for future methods to encode/decode
-type PuzzleHash string
+import (
+	"fmt"
+
+	kbls "github.com/kilic/bls12-381"
+)
+
+// PuzzleHash is the hash of a puzzle, which is what coins are locked to
+// It is marshaled the same way as Bytes32
+type PuzzleHash Bytes32
+
+// PuzzleHashFromHexString parses 0x prefixed or bare hex, which must be exactly 32 bytes
+func PuzzleHashFromHexString(hexStr string) (PuzzleHash, error) {
+	b32, err := Bytes32FromHexString(hexStr)
+	return PuzzleHash(b32), err
+}
+
+// Bytes returns the puzzle hash as a byte slice
+func (p PuzzleHash) Bytes() []byte {
+	return p[:]
+}
+
+// String returns the puzzle hash as 0x prefixed hex
+func (p PuzzleHash) String() string {
+	return Bytes32(p).String()
+}
+
+// IsZero returns true if every byte is zero
+func (p PuzzleHash) IsZero() bool {
+	return Bytes32(p).IsZero()
+}
+
+// MarshalText marshals the puzzle hash to 0x prefixed hex
+func (p PuzzleHash) MarshalText() ([]byte, error) {
+	return Bytes32(p).MarshalText()
+}
+
+// UnmarshalText unmarshals 0x prefixed or bare hex
+func (p *PuzzleHash) UnmarshalText(text []byte) error {
+	return (*Bytes32)(p).UnmarshalText(text)
+}
 
 // SerializedProgram Just represent as a string for now
 type SerializedProgram string
@@ -11,13 +49,132 @@ type ClassgroupElement struct {
 	Data string `json:"data"`
 }
 
-// EndOfSubSlotBundle end of subslot bundle
-type EndOfSubSlotBundle struct {
-	// @TODO
-}
-
-// G1Element String for now, can make better later if we need
+// G1Element is a hex encoded compressed BLS12-381 G1 point, such as a public key
+// The point is checked when it is unmarshaled. Use the bls package to verify signatures with it
 type G1Element string
 
-// G2Element String for now, can make better later if we need
+// G2Element is a hex encoded compressed BLS12-381 G2 point, such as a signature
+// The point is checked when it is unmarshaled. Use the bls package to verify it
 type G2Element string
+
+// Bytes decodes the hex encoded element
+func (e G1Element) Bytes() ([]byte, error) {
+	return decodeHexString(string(e))
+}
+
+// Bytes decodes the hex encoded element
+func (e G2Element) Bytes() ([]byte, error) {
+	return decodeHexString(string(e))
+}
+
+// Validate checks the element is 0x prefixed or bare hex of a 48 byte compressed point, and that the point is on the
+// curve and in the G1 subgroup
+func (e G1Element) Validate() error {
+	b, err := decodeElement("G1", string(e), G1ElementSize)
+	if err != nil {
+		return err
+	}
+	if _, err = kbls.NewG1().FromCompressed(b); err != nil {
+		return fmt.Errorf("invalid G1 element: %w", err)
+	}
+	return nil
+}
+
+// Validate checks the element is 0x prefixed or bare hex of a 96 byte compressed point, and that the point is on the
+// curve and in the G2 subgroup
+func (e G2Element) Validate() error {
+	b, err := decodeElement("G2", string(e), G2ElementSize)
+	if err != nil {
+		return err
+	}
+	if _, err = kbls.NewG2().FromCompressed(b); err != nil {
+		return fmt.Errorf("invalid G2 element: %w", err)
+	}
+	return nil
+}
+
+// UnmarshalText validates the element when it is unmarshaled. An empty string is allowed, since some endpoints
+// return empty strings in place of missing values
+func (e *G1Element) UnmarshalText(text []byte) error {
+	if len(text) > 0 {
+		if err := G1Element(text).Validate(); err != nil {
+			return err
+		}
+	}
+	*e = G1Element(text)
+	return nil
+}
+
+// UnmarshalText validates the element when it is unmarshaled. An empty string is allowed, since some endpoints
+// return empty strings in place of missing values
+func (e *G2Element) UnmarshalText(text []byte) error {
+	if len(text) > 0 {
+		if err := G2Element(text).Validate(); err != nil {
+			return err
+		}
+	}
+	*e = G2Element(text)
+	return nil
+}
+
+// decodeElement decodes the hex of a compressed point, checking it is the right length for the group
+func decodeElement(group string, hexStr string, size int) ([]byte, error) {
+	b, err := decodeHexString(hexStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s element: %w", group, err)
+	}
+	if len(b) != size {
+		return nil, fmt.Errorf("invalid %s element: expected %d bytes, got %d", group, size, len(b))
+	}
+	return b, nil
+}
+
+// Stream writes the program in the streamable format
+func (p *SerializedProgram) Stream(w *StreamWriter) {
+	w.WriteProgram(string(*p))
+}
+
+// Parse reads the program from the streamable format
+func (p *SerializedProgram) Parse(r *StreamReader) {
+	*p = SerializedProgram(r.ReadProgram())
+}
+
+// Stream writes the classgroup element in the streamable format
+func (c *ClassgroupElement) Stream(w *StreamWriter) {
+	w.WriteFixedBytes(c.Data, ClassgroupElementSize)
+}
+
+// Parse reads the classgroup element from the streamable format
+func (c *ClassgroupElement) Parse(r *StreamReader) {
+	c.Data = r.ReadFixedBytes(ClassgroupElementSize)
+}
+
+// Stream writes the G1 element in the streamable format
+func (g *G1Element) Stream(w *StreamWriter) {
+	w.WriteFixedBytes(string(*g), G1ElementSize)
+}
+
+// Parse reads the G1 element from the streamable format
+func (g *G1Element) Parse(r *StreamReader) {
+	*g = G1Element(r.ReadFixedBytes(G1ElementSize))
+}
+
+// Stream writes the G2 element in the streamable format
+func (g *G2Element) Stream(w *StreamWriter) {
+	w.WriteFixedBytes(string(*g), G2ElementSize)
+}
+
+// Parse reads the G2 element from the streamable format
+func (g *G2Element) Parse(r *StreamReader) {
+	*g = G2Element(r.ReadFixedBytes(G2ElementSize))
+}
+
+// Stream writes the puzzle hash in the streamable format
+func (p *PuzzleHash) Stream(w *StreamWriter) {
+	w.WriteBytes32(Bytes32(*p))
+}
+
+// Parse reads the puzzle hash from the streamable format
+func (p *PuzzleHash) Parse(r *StreamReader) {
+	*p = PuzzleHash(r.ReadBytes32())
+}
@@ -0,0 +1,41 @@
+package types_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/forks-lab/go-stai-libs/pkg/types"
+)
+
+// g1Generator is the compressed generator of G1
+const g1Generator = "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+
+func TestElementJSON(t *testing.T) {
+	// The compressed point at infinity is a valid G2 element
+	infinity := "0xc0" + strings.Repeat("00", 95)
+
+	var bundle types.SpendBundle
+	assert.NoError(t, json.Unmarshal([]byte(`{"aggregated_signature":"`+infinity+`","coin_solutions":[]}`), &bundle))
+	assert.Equal(t, types.G2Element(infinity), bundle.AggregatedSignature)
+	assert.NoError(t, bundle.AggregatedSignature.Validate())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"aggregated_signature":"`+hexBytes(0xc0, 48)+`"}`), &bundle))
+	assert.Error(t, json.Unmarshal([]byte(`{"aggregated_signature":"0xzz"}`), &bundle))
+	// The right length, but the infinity flag is set on a point that isn't zero
+	assert.Error(t, json.Unmarshal([]byte(`{"aggregated_signature":"`+hexBytes(0xc0, 96)+`"}`), &bundle))
+
+	var proof types.ProofOfSpace
+	assert.NoError(t, json.Unmarshal([]byte(`{"plot_public_key":"`+g1Generator+`"}`), &proof))
+	assert.NoError(t, proof.PlotPublicKey.Validate())
+	assert.Error(t, json.Unmarshal([]byte(`{"plot_public_key":"`+hexBytes(0xa0, 96)+`"}`), &proof))
+	// The right length, but not on the curve
+	assert.Error(t, json.Unmarshal([]byte(`{"plot_public_key":"`+hexBytes(0xa0, 48)+`"}`), &proof))
+
+	// Empty strings are allowed in place of missing values, but are not valid elements
+	var key types.G1Element
+	assert.NoError(t, json.Unmarshal([]byte(`""`), &key))
+	assert.Error(t, key.Validate())
+}
@@ -2,7 +2,7 @@ package types
 
 // VDFInfo VDF Info
 type VDFInfo struct {
-	Challenge          string             `json:"challenge"`
+	Challenge          Bytes32            `json:"challenge"`
 	NumberOfIterations uint64             `json:"number_of_iterations"`
 	Output             *ClassgroupElement `json:"output"`
 }
@@ -13,3 +13,70 @@ type VDFProof struct {
 	Witness              string `json:"witness"`
 	NormalizedToIdentity bool   `json:"normalized_to_identity"`
 }
+
+// Stream writes the VDF info in the streamable format
+func (v *VDFInfo) Stream(w *StreamWriter) {
+	w.WriteBytes32(v.Challenge)
+	w.WriteUint64(v.NumberOfIterations)
+	if w.required("output", v.Output != nil) {
+		v.Output.Stream(w)
+	}
+}
+
+// Parse reads the VDF info from the streamable format
+func (v *VDFInfo) Parse(r *StreamReader) {
+	v.Challenge = r.ReadBytes32()
+	v.NumberOfIterations = r.ReadUint64()
+	v.Output = &ClassgroupElement{}
+	v.Output.Parse(r)
+}
+
+// Stream writes the VDF proof in the streamable format
+func (v *VDFProof) Stream(w *StreamWriter) {
+	w.WriteUint8(v.WitnessType)
+	w.WriteBytes(v.Witness)
+	w.WriteBool(v.NormalizedToIdentity)
+}
+
+// Parse reads the VDF proof from the streamable format
+func (v *VDFProof) Parse(r *StreamReader) {
+	v.WitnessType = r.ReadUint8()
+	v.Witness = r.ReadBytes()
+	v.NormalizedToIdentity = r.ReadBool()
+}
+
+// streamOptionalVDFInfo writes an optional VDF info
+func streamOptionalVDFInfo(w *StreamWriter, v *VDFInfo) {
+	w.WriteOptional(v != nil)
+	if v != nil {
+		v.Stream(w)
+	}
+}
+
+// parseOptionalVDFInfo reads an optional VDF info
+func parseOptionalVDFInfo(r *StreamReader) *VDFInfo {
+	if !r.ReadOptional() {
+		return nil
+	}
+	v := &VDFInfo{}
+	v.Parse(r)
+	return v
+}
+
+// streamOptionalVDFProof writes an optional VDF proof
+func streamOptionalVDFProof(w *StreamWriter, v *VDFProof) {
+	w.WriteOptional(v != nil)
+	if v != nil {
+		v.Stream(w)
+	}
+}
+
+// parseOptionalVDFProof reads an optional VDF proof
+func parseOptionalVDFProof(r *StreamReader) *VDFProof {
+	if !r.ReadOptional() {
+		return nil
+	}
+	v := &VDFProof{}
+	v.Parse(r)
+	return v
+}
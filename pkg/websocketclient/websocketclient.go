@@ -7,17 +7,17 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
 
 	"github.com/forks-lab/go-stai-libs/pkg/config"
+	"github.com/forks-lab/go-stai-libs/pkg/profile"
 	"github.com/forks-lab/go-stai-libs/pkg/rpcinterface"
 	"github.com/forks-lab/go-stai-libs/pkg/types"
 )
 
-const origin string = "go-stai-rpc"
-
 // WebsocketClient connects to STAI RPC via websockets
 type WebsocketClient struct {
 	config  *config.StaiConfig
@@ -27,6 +27,13 @@ type WebsocketClient struct {
 	daemonKeyPair *tls.Certificate
 	daemonDialer  *websocket.Dialer
 
+	// If set > 0, config and certificates are reloaded when they change
+	reloadInterval time.Duration
+	watcher        *config.Watcher
+
+	// lock guards the config, port, key pair, and dialer, which are replaced when the config is reloaded
+	lock sync.RWMutex
+
 	conn *websocket.Conn
 
 	listenSyncActive bool
@@ -43,7 +50,7 @@ func NewWebsocketClient(cfg *config.StaiConfig, options ...rpcinterface.ClientOp
 	c := &WebsocketClient{
 		config: cfg,
 
-		daemonPort: cfg.DaemonPort,
+		daemonPort: daemonPortFromConfig(cfg),
 	}
 
 	// Sets the default host. Can be overridden by client options
@@ -75,6 +82,8 @@ func NewWebsocketClient(cfg *config.StaiConfig, options ...rpcinterface.ClientOp
 		return nil, err
 	}
 
+	c.restartWatcher()
+
 	return c, nil
 }
 
@@ -89,6 +98,64 @@ func (c *WebsocketClient) SetBaseURL(url *url.URL) error {
 // This is not currently supported by the websocket client
 func (c *WebsocketClient) SetCacheValidTime(validTime time.Duration) {}
 
+// SetConfigReloadInterval sets how often to check config.yaml and the SSL files for changes
+// When they change, the daemon port, key pair, and dialer are rebuilt. An open connection is kept, and the
+// new settings are used the next time the client connects. An interval of 0 disables reloading
+func (c *WebsocketClient) SetConfigReloadInterval(interval time.Duration) {
+	c.reloadInterval = interval
+
+	// Before the dialer exists, the watcher is started at the end of NewWebsocketClient instead
+	c.lock.RLock()
+	initialized := c.daemonDialer != nil
+	c.lock.RUnlock()
+	if initialized {
+		c.restartWatcher()
+	}
+}
+
+func (c *WebsocketClient) restartWatcher() {
+	if c.watcher != nil {
+		c.watcher.Stop()
+		c.watcher = nil
+	}
+	if c.reloadInterval <= 0 {
+		return
+	}
+
+	c.lock.RLock()
+	cfg := c.config
+	c.lock.RUnlock()
+
+	c.watcher = config.NewWatcher(cfg, c.reloadInterval, c.reload)
+	c.watcher.Start()
+}
+
+// reload rebuilds the daemon port, key pair, and dialer from cfg
+// If anything fails to load, the existing dialer is kept
+func (c *WebsocketClient) reload(cfg *config.StaiConfig) error {
+	next := &WebsocketClient{
+		config:     cfg,
+		daemonPort: daemonPortFromConfig(cfg),
+	}
+
+	err := next.initialKeyPairs()
+	if err != nil {
+		return err
+	}
+
+	err = next.generateDialer()
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	c.config = next.config
+	c.daemonPort, c.daemonKeyPair, c.daemonDialer = next.daemonPort, next.daemonKeyPair, next.daemonDialer
+	c.lock.Unlock()
+
+	return nil
+}
+
 // NewRequest creates an RPC request for the specified service
 func (c *WebsocketClient) NewRequest(service rpcinterface.ServiceType, rpcEndpoint rpcinterface.Endpoint, opt interface{}) (*rpcinterface.Request, error) {
 	request := &rpcinterface.Request{
@@ -110,21 +177,8 @@ func (c *WebsocketClient) Do(req *rpcinterface.Request, v interface{}) (*http.Re
 		return nil, err
 	}
 
-	var destination string
-	switch req.Service {
-	case rpcinterface.ServiceDaemon:
-		destination = "daemon"
-	case rpcinterface.ServiceFullNode:
-		destination = "stai_full_node"
-	case rpcinterface.ServiceFarmer:
-		destination = "stai_farmer" // @TODO validate the correct string for this
-	case rpcinterface.ServiceHarvester:
-		destination = "stai_harvester" // @TODO validate the correct string for this
-	case rpcinterface.ServiceWallet:
-		destination = "stai_wallet"
-	case rpcinterface.ServiceCrawler:
-		destination = "stai_crawler"
-	default:
+	destination := req.Service.WebsocketName(c.profile())
+	if destination == "" {
 		return nil, fmt.Errorf("unknown service")
 	}
 
@@ -134,7 +188,7 @@ func (c *WebsocketClient) Do(req *rpcinterface.Request, v interface{}) (*http.Re
 	}
 	request := &types.WebsocketRequest{
 		Command:     string(req.Endpoint),
-		Origin:      origin,
+		Origin:      c.profile().Origin,
 		Destination: destination,
 		Data:        data,
 	}
@@ -144,9 +198,17 @@ func (c *WebsocketClient) Do(req *rpcinterface.Request, v interface{}) (*http.Re
 
 // SubscribeSelf calls subscribe for any requests that this client makes to the server
 // Different from Subscribe with a custom service - that is more for subscribing to built in events emitted by STAI
-// This call will subscribe `go-stai-rpc` origin for any requests we specifically make of the server
+// This call will subscribe the profile's origin (`go-stai-rpc` by default) for any requests we specifically make of the server
 func (c *WebsocketClient) SubscribeSelf() error {
-	return c.Subscribe(origin)
+	return c.Subscribe(c.profile().Origin)
+}
+
+// profile returns the profile of the chain the client is connected to
+func (c *WebsocketClient) profile() *profile.Profile {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.config.Profile()
 }
 
 // Subscribe adds a subscription to a particular service
@@ -174,6 +236,12 @@ func (c *WebsocketClient) ListenSync(handler rpcinterface.WebsocketResponseHandl
 	if !c.listenSyncActive {
 		c.listenSyncActive = true
 
+		err := c.ensureConnection()
+		if err != nil {
+			c.listenSyncActive = false
+			return err
+		}
+
 		for {
 			_, message, err := c.conn.ReadMessage()
 			if err != nil {
@@ -233,11 +301,19 @@ func (c *WebsocketClient) reconnectLoop() {
 	}
 }
 
+// daemonPortFromConfig returns the daemon port from config, falling back to the profile's default port
+func daemonPortFromConfig(cfg *config.StaiConfig) uint16 {
+	if cfg.DaemonPort != 0 {
+		return cfg.DaemonPort
+	}
+	return cfg.Profile().DefaultPorts.Daemon
+}
+
 // Sets the initial key pairs based on config
 func (c *WebsocketClient) initialKeyPairs() error {
 	var err error
 
-	c.daemonKeyPair, err = c.config.DaemonSSL.LoadPrivateKeyPair()
+	c.daemonKeyPair, err = c.config.DaemonSSL.LoadPrivateKeyPairFromRoot(c.config.StaiRoot)
 	if err != nil {
 		return err
 	}
@@ -263,9 +339,13 @@ func (c *WebsocketClient) generateDialer() error {
 // ensureConnection ensures there is an open websocket connection
 func (c *WebsocketClient) ensureConnection() error {
 	if c.conn == nil {
+		c.lock.RLock()
 		u := url.URL{Scheme: "wss", Host: fmt.Sprintf("%s:%d", c.baseURL.Host, c.daemonPort), Path: "/"}
+		dialer := c.daemonDialer
+		c.lock.RUnlock()
+
 		var err error
-		c.conn, _, err = c.daemonDialer.Dial(u.String(), nil)
+		c.conn, _, err = dialer.Dial(u.String(), nil)
 		if err != nil {
 			return err
 		}